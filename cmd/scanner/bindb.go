@@ -0,0 +1,299 @@
+// bindb commands - BIN database maintenance
+// File: cmd/scanner/bindb.go
+//
+// Usage:
+//
+//	scanner bindb validate [file]             Check a database for problems
+//	scanner bindb overlaps [file]             Show who wins overlapping ranges
+//	scanner bindb merge -csv feed.csv [-o out.json] [file]
+//	                                          Merge a vendor CSV feed
+//	scanner bindb diff <old.json> <new.json>  Compare two databases
+//
+// [file] defaults to the database built into the binary.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/keraattin/BasicPanScanner/internal/detector"
+	"github.com/keraattin/BasicPanScanner/internal/ui"
+)
+
+// ============================================================
+// COMMANDS
+// ============================================================
+
+var (
+	bindbValidateCommand = ui.Command{
+		Name:    "bindb validate",
+		Args:    "[file]",
+		Summary: "Check a BIN database for problems (default: built-in)",
+		Description: `Checks range ordering, overlaps between issuers of the same priority,
+card lengths and 8-digit BINs (the lookup uses 6 digits).
+Exit code 1 if errors are found.`,
+	}
+	bindbOverlapsCommand = ui.Command{
+		Name:        "bindb overlaps",
+		Args:        "[file]",
+		Summary:     "Show overlapping BIN ranges and which issuer wins",
+		Description: "Lists every BIN segment claimed by several issuers and the issuer\nthe lookup returns for each card length.",
+	}
+	bindbMergeCommand = ui.Command{
+		Name:    "bindb merge",
+		Args:    "-csv <feed> [options] [file]",
+		Summary: "Merge a vendor CSV feed into a BIN database",
+		Description: `CSV columns (header row): issuer|brand|scheme, bin|start|bin_start,
+end|bin_end, lengths (e.g. 16|19); priority, region and display_name
+are used for new issuers. 8-digit BINs are cut to 6 digits.`,
+		Examples: []string{"./scanner bindb merge -csv vendor.csv -version 3.1.0 -o bin_ranges.json"},
+	}
+	bindbDiffCommand = ui.Command{
+		Name:        "bindb diff",
+		Args:        "<old> <new>",
+		Summary:     "Show what changed between two BIN databases",
+		Description: "Use 'embedded' for the built-in database.",
+	}
+)
+
+// setupBinDBValidate defines the options of "bindb validate" (none)
+func setupBinDBValidate(fs *flag.FlagSet) func([]string) int {
+	return func(args []string) int {
+		code, err := bindbValidate(args)
+		if err != nil {
+			return exitCode(err)
+		}
+		return code
+	}
+}
+
+// setupBinDBOverlaps defines the options of "bindb overlaps" (none)
+func setupBinDBOverlaps(fs *flag.FlagSet) func([]string) int {
+	return func(args []string) int { return exitCode(bindbOverlaps(args)) }
+}
+
+// setupBinDBMerge defines the options of "bindb merge"
+func setupBinDBMerge(fs *flag.FlagSet) func([]string) int {
+	opts := &mergeOptions{}
+	fs.StringVar(&opts.csv, "csv", "", "Vendor CSV `feed` to merge (required)")
+	fs.StringVar(&opts.output, "o", "", "Output `file` (default: stdout)")
+	fs.StringVar(&opts.version, "version", "", "Record this `version` in _info")
+	fs.IntVar(&opts.priority, "priority", 50, "New issuers get this `priority`")
+	fs.StringVar(&opts.lengths, "lengths", "16", "Card `lengths` of new issuers without a lengths column")
+	return func(args []string) int { return exitCode(bindbMerge(opts, args)) }
+}
+
+// setupBinDBDiff defines the options of "bindb diff" (none)
+func setupBinDBDiff(fs *flag.FlagSet) func([]string) int {
+	return func(args []string) int { return exitCode(bindbDiff(args)) }
+}
+
+// mergeOptions holds the options of "bindb merge"
+type mergeOptions struct {
+	csv      string
+	output   string
+	version  string
+	priority int
+	lengths  string
+}
+
+// ============================================================
+// ACTIONS
+// ============================================================
+
+// bindbValidate prints every problem in a database
+// Returns exit code 1 if any error was found
+func bindbValidate(args []string) (int, error) {
+	file, label, err := readBINFileArg(args)
+	if err != nil {
+		return 1, err
+	}
+
+	issues := file.Validate()
+	errors, warnings := 0, 0
+	for _, issue := range issues {
+		fmt.Println(issue)
+		if issue.Severity == detector.BINIssueError {
+			errors++
+		} else {
+			warnings++
+		}
+	}
+
+	ranges := 0
+	for _, issuer := range file.Issuers {
+		ranges += len(issuer.Ranges)
+	}
+
+	if len(issues) > 0 {
+		fmt.Println()
+	}
+	if errors > 0 {
+		fmt.Printf("✗ %s: %d errors, %d warnings (%d issuers, %d ranges)\n", label, errors, warnings, len(file.Issuers), ranges)
+		return 1, nil
+	}
+	fmt.Printf("✓ %s: no errors, %d warnings (%d issuers, %d ranges)\n", label, warnings, len(file.Issuers), ranges)
+	return 0, nil
+}
+
+// bindbOverlaps prints the overlapping segments and their winners
+func bindbOverlaps(args []string) error {
+	file, label, err := readBINFileArg(args)
+	if err != nil {
+		return err
+	}
+
+	overlaps := file.Overlaps()
+	if len(overlaps) == 0 {
+		fmt.Printf("✓ %s: no overlapping ranges\n", label)
+		return nil
+	}
+
+	priorities := make(map[string]int)
+	for _, issuer := range file.Issuers {
+		priorities[issuer.Issuer] = issuer.Priority
+	}
+
+	for _, overlap := range overlaps {
+		var claimants []string
+		for _, name := range overlap.Issuers {
+			claimants = append(claimants, fmt.Sprintf("%s (%d)", name, priorities[name]))
+		}
+
+		lengths := make([]int, 0, len(overlap.Winners))
+		for length := range overlap.Winners {
+			lengths = append(lengths, length)
+		}
+		sort.Ints(lengths)
+
+		var winners []string
+		for _, length := range lengths {
+			winners = append(winners, fmt.Sprintf("%d→%s", length, overlap.Winners[length]))
+		}
+
+		tied := ""
+		if overlap.Tied {
+			tied = "  ⚠ tie"
+		}
+		fmt.Printf("%s-%s  %s\n", overlap.Start, overlap.End, strings.Join(claimants, " > "))
+		fmt.Printf("               winner: %s%s\n", strings.Join(winners, ", "), tied)
+	}
+
+	fmt.Printf("\n%d overlapping segments in %s\n", len(overlaps), label)
+	return nil
+}
+
+// bindbMerge merges a vendor CSV feed and writes the result
+func bindbMerge(opts *mergeOptions, args []string) error {
+	if opts.csv == "" {
+		return fmt.Errorf("bindb merge: -csv is required")
+	}
+
+	defaults := detector.MergeDefaults{Priority: opts.priority}
+	for _, value := range splitList(opts.lengths) {
+		length, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("bindb merge: invalid length '%s'", value)
+		}
+		defaults.Lengths = append(defaults.Lengths, length)
+	}
+
+	file, label, err := readBINFileArg(args)
+	if err != nil {
+		return err
+	}
+
+	feed, err := os.Open(opts.csv)
+	if err != nil {
+		return fmt.Errorf("failed to open feed: %w", err)
+	}
+	defer feed.Close()
+
+	stats, err := file.MergeCSV(feed, defaults)
+	if err != nil {
+		return fmt.Errorf("%s: %w", opts.csv, err)
+	}
+
+	if opts.version != "" {
+		file.Version = opts.version
+	}
+	file.LastUpdated = time.Now().Format("2006-01-02")
+
+	// Refuse to write a file the scanner could not load
+	if _, err := file.Database(); err != nil {
+		return fmt.Errorf("merged database does not load: %w", err)
+	}
+
+	data, err := file.Encode()
+	if err != nil {
+		return err
+	}
+	if opts.output == "" {
+		os.Stdout.Write(data)
+	} else if err := os.WriteFile(opts.output, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", opts.output, err)
+	}
+
+	// Summary goes to stderr so stdout can be redirected to a file
+	fmt.Fprintf(os.Stderr, "✓ Merged %s into %s: %d rows, %d ranges added, %d already present\n",
+		opts.csv, label, stats.Rows, stats.Added, stats.Duplicates)
+	if len(stats.NewIssuers) > 0 {
+		fmt.Fprintf(os.Stderr, "  New issuers: %s\n", strings.Join(stats.NewIssuers, ", "))
+	}
+	if stats.Widened > 0 {
+		fmt.Fprintf(os.Stderr, "  ⚠ %d rows used 8-digit BINs and were widened to 6 digits\n", stats.Widened)
+	}
+	for _, issue := range file.Validate() {
+		fmt.Fprintf(os.Stderr, "  %s\n", issue)
+	}
+	return nil
+}
+
+// bindbDiff prints the changes between two databases
+func bindbDiff(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("bindb diff: expected <old> <new>")
+	}
+
+	oldFile, _, err := readBINFileArg(args[:1])
+	if err != nil {
+		return err
+	}
+	newFile, _, err := readBINFileArg(args[1:])
+	if err != nil {
+		return err
+	}
+
+	changes := detector.DiffBINFiles(oldFile, newFile)
+	for _, change := range changes {
+		fmt.Println(change)
+	}
+	if len(changes) == 0 {
+		fmt.Println("✓ No differences")
+	}
+	return nil
+}
+
+// readBINFileArg reads the database named by the first argument
+// (the built-in database if there is none)
+//
+// Returns:
+//   - *detector.BINFile: Database content
+//   - string: Name to show in messages
+//   - error: Read or parse error
+func readBINFileArg(args []string) (*detector.BINFile, string, error) {
+	if len(args) > 1 {
+		return nil, "", fmt.Errorf("unexpected arguments: %s", strings.Join(args[1:], " "))
+	}
+	if len(args) == 0 || args[0] == detector.EmbeddedSource {
+		file, err := detector.EmbeddedBINFile()
+		return file, "built-in database", err
+	}
+	file, err := detector.ReadBINFile(args[0])
+	return file, args[0], err
+}
@@ -0,0 +1,215 @@
+// Command table and dispatch
+// File: cmd/scanner/commands.go
+//
+// Every command is one entry in commandTable: its help text (ui.Command)
+// and a setup function that defines its flags on a fresh flag set and
+// returns the function that runs it. The same setup is used to parse the
+// command line and to generate the help, so both always agree.
+//
+// Commands with an action ("report convert", "bindb merge") are listed
+// under their full name; "./scanner bindb" lists the actions of the group.
+//
+// There is deliberately no "serve" command: an HTTP service is embedded
+// through pkg/panscan, not run by this CLI (see the README).
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/keraattin/BasicPanScanner/internal/ui"
+)
+
+// command is one command of the scanner
+type command struct {
+	ui.Command
+
+	// setup defines the command's flags on fs and returns the function
+	// that runs the command with the remaining (non-flag) arguments
+	setup func(fs *flag.FlagSet) func(args []string) int
+}
+
+// commandTable returns all commands in help order
+func commandTable() []command {
+	return []command{
+		{scanCommand, func(fs *flag.FlagSet) func([]string) int {
+			opts := registerScanFlags(fs)
+			return func(args []string) int { return runScan(opts, args) }
+		}},
+		{reportConvertCommand, setupReportConvert},
+		{configValidateCommand, setupConfigValidate},
+		{configInitCommand, setupConfigInit},
+		{configShowCommand, setupConfigShow},
+		{bindbValidateCommand, setupBinDBValidate},
+		{bindbOverlapsCommand, setupBinDBOverlaps},
+		{bindbMergeCommand, setupBinDBMerge},
+		{bindbDiffCommand, setupBinDBDiff},
+		{lookupCommand, setupLookup},
+	}
+}
+
+// run dispatches the command line to a command
+//
+// Parameters:
+//   - args: Command line without the program name
+//
+// Returns:
+//   - int: Process exit code
+func run(args []string) int {
+	commands := commandTable()
+
+	switch {
+	case len(args) == 0 || (len(args) == 1 && isHelpFlag(args[0])):
+		ui.ShowHelp(Version, helpEntries(commands))
+		return 0
+
+	case args[0] == "help":
+		return showHelpFor(commands, args[1:])
+
+	case strings.HasPrefix(args[0], "-"):
+		// Options without a command: the scanner used to have only the
+		// scan flags, so "./scanner -path /var/log" still runs a scan
+		return runCommand(findCommand(commands, "scan"), args)
+	}
+
+	if cmd, rest := matchCommand(commands, args); cmd != nil {
+		return runCommand(cmd, rest)
+	}
+
+	// A group without (a valid) action: list the group's actions
+	if group := commandGroup(commands, args[0]); len(group) > 0 {
+		if len(args) > 1 && !isHelpFlag(args[1]) {
+			fmt.Fprintf(os.Stderr, "Error: unknown %s action '%s'\n", args[0], args[1])
+		}
+		ui.ShowCommandGroup(args[0], group)
+		if len(args) > 1 && isHelpFlag(args[1]) {
+			return 0
+		}
+		return 1
+	}
+
+	fmt.Fprintf(os.Stderr, "Error: unknown command '%s'\n", args[0])
+	fmt.Fprintln(os.Stderr, "Run './scanner help' for the list of commands")
+	return 1
+}
+
+// runCommand parses a command's flags and runs it
+// "-help" shows the command's help
+func runCommand(cmd *command, args []string) int {
+	fs := flag.NewFlagSet(cmd.Name, flag.ContinueOnError)
+	fs.SetOutput(io.Discard) // Errors are printed below, help by ShowCommandHelp
+	runner := cmd.setup(fs)
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			ui.ShowCommandHelp(cmd.Command, fs)
+			return 0
+		}
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Run './scanner help %s' for usage information\n", cmd.Name)
+		return 1
+	}
+
+	return runner(fs.Args())
+}
+
+// showHelpFor handles "./scanner help [command]"
+func showHelpFor(commands []command, args []string) int {
+	if len(args) == 0 {
+		ui.ShowHelp(Version, helpEntries(commands))
+		return 0
+	}
+
+	if cmd, rest := matchCommand(commands, args); cmd != nil && len(rest) == 0 {
+		fs := flag.NewFlagSet(cmd.Name, flag.ContinueOnError)
+		cmd.setup(fs)
+		ui.ShowCommandHelp(cmd.Command, fs)
+		return 0
+	}
+
+	if group := commandGroup(commands, args[0]); len(group) > 0 && len(args) == 1 {
+		ui.ShowCommandGroup(args[0], group)
+		return 0
+	}
+
+	fmt.Fprintf(os.Stderr, "Error: unknown command '%s'\n", strings.Join(args, " "))
+	return 1
+}
+
+// matchCommand finds the command named by the first arguments
+//
+// Returns:
+//   - *command: Matching command (nil if none)
+//   - []string: Arguments after the command name
+func matchCommand(commands []command, args []string) (*command, []string) {
+	for i := range commands {
+		words := strings.Fields(commands[i].Name)
+		if len(args) < len(words) {
+			continue
+		}
+
+		matched := true
+		for j, word := range words {
+			if args[j] != word {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return &commands[i], args[len(words):]
+		}
+	}
+	return nil, nil
+}
+
+// findCommand returns the command with the given name
+func findCommand(commands []command, name string) *command {
+	for i := range commands {
+		if commands[i].Name == name {
+			return &commands[i]
+		}
+	}
+	return nil
+}
+
+// commandGroup returns the help entries of the commands "<group> <action>"
+func commandGroup(commands []command, group string) []ui.Command {
+	var entries []ui.Command
+	for _, cmd := range commands {
+		if strings.HasPrefix(cmd.Name, group+" ") {
+			entries = append(entries, cmd.Command)
+		}
+	}
+	return entries
+}
+
+// helpEntries returns the help entries of all commands
+func helpEntries(commands []command) []ui.Command {
+	entries := make([]ui.Command, len(commands))
+	for i, cmd := range commands {
+		entries[i] = cmd.Command
+	}
+	return entries
+}
+
+// isHelpFlag reports whether arg asks for help
+func isHelpFlag(arg string) bool {
+	switch arg {
+	case "-h", "-help", "--help":
+		return true
+	}
+	return false
+}
+
+// exitCode prints a command error and converts it to an exit code
+func exitCode(err error) int {
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "✗ %v\n", err)
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,158 @@
+// config commands - create and check configuration files
+// File: cmd/scanner/config_cmd.go
+//
+// Usage:
+//
+//	scanner config validate [file]              Check a config file
+//	scanner config init [-force] [file]         Write the default config
+//	scanner config show-effective [scan options] Config after CLI overrides
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/keraattin/BasicPanScanner/internal/config"
+	"github.com/keraattin/BasicPanScanner/internal/filter"
+	"github.com/keraattin/BasicPanScanner/internal/ui"
+)
+
+// ============================================================
+// COMMANDS
+// ============================================================
+
+var (
+	configValidateCommand = ui.Command{
+		Name:    "config validate",
+		Args:    "[file]",
+		Summary: "Check a configuration file (default: config.json)",
+		Description: `Loads the file the way a scan does and also checks what a scan would
+only find out later: path rules, age and owner filters, size and
+timeout values, and the bin_database file.`,
+	}
+	configInitCommand = ui.Command{
+		Name:    "config init",
+		Args:    "[-force] [file]",
+		Summary: "Write the default configuration (default: config.json)",
+	}
+	configShowCommand = ui.Command{
+		Name:    "config show-effective",
+		Args:    "[scan options]",
+		Summary: "Print the configuration a scan would use, after CLI overrides",
+		Description: `Accepts the same options as 'scan' and prints the resulting
+configuration as JSON, without scanning.`,
+		Examples: []string{"./scanner config show-effective -config prod.json -mode whitelist -ext txt,log"},
+	}
+)
+
+// setupConfigValidate defines the options of "config validate" (none)
+func setupConfigValidate(fs *flag.FlagSet) func([]string) int {
+	return func(args []string) int { return exitCode(configValidate(args)) }
+}
+
+// setupConfigInit defines the options of "config init"
+func setupConfigInit(fs *flag.FlagSet) func([]string) int {
+	force := fs.Bool("force", false, "Overwrite an existing file")
+	return func(args []string) int { return exitCode(configInit(*force, args)) }
+}
+
+// setupConfigShow defines the options of "config show-effective"
+// (the scan options)
+func setupConfigShow(fs *flag.FlagSet) func([]string) int {
+	opts := registerScanFlags(fs)
+	return func(args []string) int { return exitCode(configShow(opts)) }
+}
+
+// ============================================================
+// ACTIONS
+// ============================================================
+
+// configValidate checks a configuration file
+func configValidate(args []string) error {
+	path, err := configFileArg(args)
+	if err != nil {
+		return err
+	}
+
+	// Load runs config.Validate (warnings are printed)
+	cfg, err := config.Load(path)
+	if err != nil {
+		return err
+	}
+
+	if _, err := filter.NewPathFilter(cfg.PathRules); err != nil {
+		return fmt.Errorf("path_rules: %w", err)
+	}
+	if _, err := newAttributeFilter(cfg); err != nil {
+		return err
+	}
+	if _, err := cfg.GetMaxFileSizeBytes(); err != nil {
+		return fmt.Errorf("max_file_size: %w", err)
+	}
+	if _, err := cfg.GetFileTimeout(); err != nil {
+		return fmt.Errorf("file_timeout: %w", err)
+	}
+	if _, err := cfg.GetMaxFileMemoryBytes(); err != nil {
+		return fmt.Errorf("max_file_memory: %w", err)
+	}
+	if _, err := loadBINDatabase(cfg.BINDatabase); err != nil {
+		return fmt.Errorf("bin_database: %w", err)
+	}
+
+	fmt.Printf("✓ %s is valid (%s mode)\n", path, cfg.ScanMode)
+	return nil
+}
+
+// configInit writes the default configuration
+func configInit(force bool, args []string) error {
+	path, err := configFileArg(args)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(path); err == nil && !force {
+		return fmt.Errorf("%s already exists (use -force to overwrite)", path)
+	}
+
+	if err := config.Default().Save(path); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Default configuration written to %s\n", path)
+	return nil
+}
+
+// configShow prints the configuration after CLI overrides as JSON
+func configShow(opts *scanOptions) error {
+	cfg, err := config.Load(opts.configFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "⚠ Warning: Could not load %s: %v\n", opts.configFile, err)
+		fmt.Fprintln(os.Stderr, "  Using default configuration")
+		cfg = config.Default()
+	}
+
+	if err := opts.applyTo(cfg, io.Discard); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// configFileArg returns the file named by the arguments (default: config.json)
+func configFileArg(args []string) (string, error) {
+	switch len(args) {
+	case 0:
+		return "config.json", nil
+	case 1:
+		return args[0], nil
+	}
+	return "", fmt.Errorf("expected one configuration file, got %d", len(args))
+}
@@ -0,0 +1,190 @@
+// lookup command - explain how a single number is classified
+// File: cmd/scanner/lookup.go
+//
+// Usage:
+//
+//	scanner lookup [-bin-db file] <pan|masked pan|bin> ...
+//
+// Full PANs are shown masked in the output.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/keraattin/BasicPanScanner/internal/detector"
+	"github.com/keraattin/BasicPanScanner/internal/ui"
+)
+
+// lookupCommand describes the lookup command for the help screens
+var lookupCommand = ui.Command{
+	Name:    "lookup",
+	Args:    "[options] <number> ...",
+	Summary: "Explain how a PAN or BIN is classified",
+	Description: `Shows the matching issuer, every candidate range with its priority and
+lengths, the Luhn result and the pipeline stage that accepts or rejects
+the number. Full PANs are printed masked.
+
+Numbers:
+    6011000990139424       Full PAN (separators allowed, quote if spaces)
+    601100******9424       Masked PAN (*, x or #; Luhn is not checked)
+    601100                 BIN (6-8 digits; card length is not checked)`,
+}
+
+// setupLookup defines the options of the lookup command
+func setupLookup(fs *flag.FlagSet) func([]string) int {
+	binDBPath := fs.String("bin-db", "", "BIN database JSON `file` (default: built-in)")
+	return func(args []string) int { return runLookup(*binDBPath, args) }
+}
+
+// runLookup runs the lookup command
+//
+// Parameters:
+//   - binDBPath: BIN database file ("" = built-in)
+//   - numbers: PANs, masked PANs or BINs to explain
+//
+// Returns:
+//   - int: Process exit code (0 ok, 1 invalid input or database error)
+func runLookup(binDBPath string, numbers []string) int {
+	if len(numbers) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: no number given")
+		fmt.Fprintln(os.Stderr, "Run './scanner help lookup' for usage information")
+		return 1
+	}
+
+	db, err := loadBINDatabase(binDBPath)
+	if err != nil {
+		return exitCode(err)
+	}
+
+	code := 0
+	printed := 0
+	for _, input := range numbers {
+		exp, err := db.Explain(input)
+		if err != nil {
+			code = exitCode(err)
+			continue
+		}
+
+		if printed > 0 {
+			fmt.Println()
+		}
+		printExplanation(exp)
+		printed++
+	}
+	return code
+}
+
+// printExplanation prints one lookup result
+//
+// Example output:
+//
+//	601100******9424  (16 digits, BIN 601100)
+//	  Candidates:
+//	    → Discover           601100-601199  priority 76   lengths 16,19          length ✓
+//	      RuPay              600000-609999  priority 74   lengths 16-19          length ✓
+//	  Pipeline:
+//	    ✓ format   matches the 16-digit pattern
+//	    ✓ issuer   Discover
+//	    ✓ luhn     checksum valid
+//	  Result: ACCEPTED as Discover
+func printExplanation(exp *detector.Explanation) {
+	// Never echo a full PAN
+	shown := strings.TrimSpace(exp.Input)
+	if exp.Digits != "" {
+		shown = detector.MaskCardNumber(exp.Digits)
+	}
+
+	switch {
+	case exp.Length == 0:
+		fmt.Printf("%s  (BIN %s, card length unknown)\n", shown, exp.BIN)
+	case exp.Masked:
+		fmt.Printf("%s  (%d digits, masked, BIN %s)\n", shown, exp.Length, exp.BIN)
+	default:
+		fmt.Printf("%s  (%d digits, BIN %s)\n", shown, exp.Length, exp.BIN)
+	}
+
+	fmt.Println("  Candidates:")
+	if len(exp.Candidates) == 0 {
+		fmt.Println("    (no range contains this BIN)")
+	}
+	for _, c := range exp.Candidates {
+		marker := " "
+		if c.Winner {
+			marker = "→"
+		}
+
+		var notes []string
+		if !c.Active {
+			notes = append(notes, "inactive")
+		}
+		if exp.Length != 0 {
+			if c.LengthOK {
+				notes = append(notes, "length ✓")
+			} else {
+				notes = append(notes, fmt.Sprintf("length %d ✗", exp.Length))
+			}
+		}
+
+		line := fmt.Sprintf("    %s %-18s %s-%s  priority %-3d  lengths %-14s %s",
+			marker, c.Issuer, c.Range.Start, c.Range.End, c.Priority,
+			formatLengths(c.Lengths), strings.Join(notes, ", "))
+		fmt.Println(strings.TrimRight(line, " "))
+	}
+
+	fmt.Println("  Pipeline:")
+	for _, stage := range exp.Stages {
+		symbol := map[string]string{
+			detector.StagePass: "✓",
+			detector.StageFail: "✗",
+			detector.StageSkip: "-",
+		}[stage.Status]
+		fmt.Printf("    %s %-8s %s\n", symbol, stage.Name, stage.Detail)
+	}
+
+	switch {
+	case exp.Accepted:
+		fmt.Printf("  Result: ACCEPTED as %s\n", exp.Issuer)
+	case exp.Issuer != "" && !hasFailedStage(exp):
+		fmt.Printf("  Result: %s (accepted if the remaining stages pass)\n", exp.Issuer)
+	default:
+		for _, stage := range exp.Stages {
+			if stage.Status == detector.StageFail {
+				fmt.Printf("  Result: REJECTED at %s stage (%s)\n", stage.Name, stage.Detail)
+				break
+			}
+		}
+	}
+}
+
+// hasFailedStage reports whether any pipeline stage failed
+func hasFailedStage(exp *detector.Explanation) bool {
+	for _, stage := range exp.Stages {
+		if stage.Status == detector.StageFail {
+			return true
+		}
+	}
+	return false
+}
+
+// formatLengths formats card lengths as "16,19" or "13-19" for runs
+func formatLengths(lengths []int) string {
+	var parts []string
+	for i := 0; i < len(lengths); {
+		j := i
+		for j+1 < len(lengths) && lengths[j+1] == lengths[j]+1 {
+			j++
+		}
+		if j-i >= 2 {
+			parts = append(parts, fmt.Sprintf("%d-%d", lengths[i], lengths[j]))
+		} else {
+			for k := i; k <= j; k++ {
+				parts = append(parts, fmt.Sprint(lengths[k]))
+			}
+		}
+		i = j + 1
+	}
+	return strings.Join(parts, ",")
+}
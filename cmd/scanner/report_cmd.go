@@ -0,0 +1,104 @@
+// report commands - work with saved reports
+// File: cmd/scanner/report_cmd.go
+//
+// Usage:
+//
+//	scanner report convert -o scan.html [-o scan.pdf ...] scan.json
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/keraattin/BasicPanScanner/internal/report"
+	"github.com/keraattin/BasicPanScanner/internal/ui"
+)
+
+// reportConvertCommand describes "report convert" for the help screens
+var reportConvertCommand = ui.Command{
+	Name:    "report convert",
+	Args:    "-o <file> [-o <file> ...] <report.json>",
+	Summary: "Re-render a JSON report in another format without rescanning",
+	Description: `Reads a report written with -output scan.json and exports it again.
+The format is chosen from the -o extension (.json, .jsonl, .csv, .html, .txt,
+.xml, .pdf, .sarif, .md, .junit.xml); several -o files are written at once.`,
+	Examples: []string{
+		"./scanner scan -path /data -output scan.json",
+		"./scanner report convert -o scan.html scan.json",
+		"./scanner report convert -o scan.pdf -o scan.sarif scan.json",
+		"./scanner report convert -o summary.md -o results.junit.xml scan.json",
+	},
+}
+
+// setupReportConvert defines the options of "report convert"
+func setupReportConvert(fs *flag.FlagSet) func([]string) int {
+	var outputs stringList
+	fs.Var(&outputs, "o", "Output `file` (required, repeatable)")
+	return func(args []string) int { return exitCode(reportConvert(outputs, args)) }
+}
+
+// reportConvert loads a JSON report and exports it to every output
+func reportConvert(outputs []string, args []string) error {
+	if len(outputs) == 0 {
+		return fmt.Errorf("report convert: -o is required")
+	}
+	if len(args) != 1 {
+		return fmt.Errorf("report convert: expected one JSON report")
+	}
+	if err := report.CheckOutputs(outputs); err != nil {
+		return err
+	}
+
+	rep, err := report.LoadJSON(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Report: %d cards in %d files\n", rep.CardsFound, rep.Statistics.FilesWithCards)
+	return writeReports(rep, outputs)
+}
+
+// writeReports exports a report to several files concurrently and
+// prints one status line per file
+//
+// Parameters:
+//   - rep: Report to export
+//   - outputs: Output files (format from the extension)
+//
+// Returns:
+//   - error: Error naming how many files failed (nil if all succeeded)
+//
+// Example output:
+//
+//	✓ json   reports/report.json (4ms)
+//	✗ pdf    reports/report.pdf: permission denied
+func writeReports(rep *report.Report, outputs []string) error {
+	failed := 0
+	ready := make([]string, 0, len(outputs))
+	for _, output := range outputs {
+		// -output-dir may not exist yet
+		if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+			failed++
+			format, _ := report.FormatForFile(output)
+			fmt.Fprintf(os.Stderr, "✗ %-7s %s: %v\n", format.Name, output, err)
+			continue
+		}
+		ready = append(ready, output)
+	}
+
+	for _, res := range rep.ExportAll(ready) {
+		if res.Err != nil {
+			failed++
+			fmt.Fprintf(os.Stderr, "✗ %-7s %s: %v\n", res.Format, res.Filename, res.Err)
+			continue
+		}
+		fmt.Printf("✓ %-7s %s (%s)\n", res.Format, res.Filename, report.FormatDuration(res.Duration))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to generate %d of %d reports", failed, len(outputs))
+	}
+	return nil
+}
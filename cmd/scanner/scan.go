@@ -0,0 +1,951 @@
+// scan command - scan files, git history or S3 for card numbers
+// File: cmd/scanner/scan.go
+//
+// RUN ORDER:
+//  1. Check the scan source (paths, git, S3 or database)
+//  2. Show banner
+//  3. Load configuration
+//  4. Apply CLI overrides
+//  5. Initialize BIN database (CRITICAL)
+//  6. Create filters and scanner
+//  7. Run scan
+//  8. Generate report
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/keraattin/BasicPanScanner/internal/config"
+	"github.com/keraattin/BasicPanScanner/internal/filter"
+	"github.com/keraattin/BasicPanScanner/internal/report"
+	"github.com/keraattin/BasicPanScanner/internal/scanner"
+	"github.com/keraattin/BasicPanScanner/internal/ui"
+)
+
+// scanCommand describes the scan command for the help screens
+var scanCommand = ui.Command{
+	Name:    "scan",
+	Args:    "[options] [path ...]",
+	Summary: "Scan files, git history or S3 for card numbers",
+	Description: `Paths can be given with -path, -files-from or as arguments.
+Path rules apply in order and the last match wins. Ages are durations
+(30d, 12h, 2w, 90m) or dates (2025-01-31). S3 credentials come from
+AWS_ACCESS_KEY_ID / AWS_SECRET_ACCESS_KEY / AWS_SESSION_TOKEN.
+
+Scan modes: whitelist scans ONLY the listed extensions, blacklist
+(default) scans everything EXCEPT them.
+
+Exit codes: 0 = no cards found, 1 = error, 2 = cards found.`,
+	Groups: []ui.FlagGroup{
+		{Title: "Required (one of)", Flags: []string{"path", "files-from"}},
+		{Title: "Options", Flags: []string{"output", "format", "output-dir", "config", "mode", "ext", "exclude",
+			"include-path", "exclude-path", "ignore-files", "follow-symlinks", "xdev",
+			"detect", "bin-db", "workers"}},
+		{Title: "File Filters", Flags: []string{"newer-than", "older-than", "time-field",
+			"owner", "group", "min-size", "file-timeout", "max-file-memory"}},
+		{Title: "SIEM Output", Flags: []string{"syslog", "syslog-format", "syslog-ca"}},
+		{Title: "Notifications", Flags: []string{"webhook", "webhook-type", "webhook-on",
+			"webhook-secret", "webhook-template", "webhook-retries"}},
+		{Title: "Git History Scan (instead of -path)", Flags: []string{"git"}},
+		{Title: "Object Storage Scan (instead of -path)", Flags: []string{"s3", "s3-endpoint", "s3-region"}},
+	},
+	Examples: []string{
+		"# Use config.json settings (blacklist mode by default)",
+		"./scanner scan /var/log",
+		"",
+		"# Force whitelist mode (scan only .txt and .log)",
+		"./scanner scan -path /var/log -mode whitelist -ext txt,log",
+		"",
+		"# Fast scan with 4 workers",
+		"./scanner scan -path /var/log -workers 4 -output report.html",
+		"",
+		"# JSON for machines, HTML for people and SARIF for CI from one scan",
+		"./scanner scan -path /srv -format json,html,sarif -output-dir reports",
+		"",
+		"# Findings to QRadar in real time (LEEF over TCP syslog)",
+		"./scanner scan -path /srv -syslog tcp://qradar.example.com:514 -syslog-format leef",
+		"",
+		"# Tell the team on Slack when the nightly scan is done",
+		"./scanner scan -path /srv -webhook https://hooks.slack.com/services/T000/B000/XXXX",
+		"",
+		"# Several mount points in one report",
+		"./scanner scan -path /mnt/share1 -path /mnt/share2 -output shares.json",
+		"",
+		"# Only files changed in the last 30 days, owned by www-data",
+		"./scanner scan -path /var/www -newer-than 30d -owner www-data",
+		"",
+		"# Explicit file list from another tool",
+		"find /srv -name '*.csv' -print0 | ./scanner scan -files-from -",
+		"",
+		"# Find cards committed (and maybe deleted) anywhere in git history",
+		"./scanner scan -git /src/payments-api -output history.json",
+		"",
+		"# Scan a bucket prefix on a local MinIO with 8 workers",
+		"./scanner scan -s3 s3://log-archive/2025/ -s3-endpoint http://localhost:9000 -workers 8",
+	},
+}
+
+// scanOptions holds the command line options of the scan command
+// "config show-effective" parses the same options
+type scanOptions struct {
+	configFile string
+	paths      stringList
+	filesFrom  string
+	outputs    stringList
+	formats    string
+	outputDir  string
+	workers    int
+
+	// SIEM output (syslog with CEF or LEEF payload)
+	syslog       string
+	syslogFormat string
+	syslogCA     string
+
+	// Webhook notifications (Slack, Teams or any HTTP endpoint)
+	webhook         string
+	webhookType     string
+	webhookOn       string
+	webhookSecret   string
+	webhookTemplate string
+	webhookRetries  int
+
+	// Overrides of config.json values (applyTo)
+	mode           string
+	extensions     string
+	exclude        string
+	pathRules      []string
+	newerThan      string
+	olderThan      string
+	timeField      string
+	owner          string
+	group          string
+	minSize        string
+	fileTimeout    string
+	maxFileMemory  string
+	ignoreFiles    string
+	followSymlinks bool
+	xdev           bool
+	detect         string
+	binDB          string
+
+	// Other sources (instead of paths)
+	git        string
+	s3         string
+	s3Endpoint string
+	s3Region   string
+}
+
+// registerScanFlags defines the scan options on a flag set
+//
+// Parameters:
+//   - fs: Flag set of the scan (or config show-effective) command
+//
+// Returns:
+//   - *scanOptions: Values, filled in by fs.Parse
+func registerScanFlags(fs *flag.FlagSet) *scanOptions {
+	o := &scanOptions{}
+
+	fs.Var(&o.paths, "path", "Directory or file `path` to scan (repeat for several: -path /a -path /b)")
+	fs.StringVar(&o.filesFrom, "files-from", "", "Scan paths listed in a `file` (one per line or NUL-separated; '-' = stdin)")
+	fs.Var(&o.outputs, "output", "Save results to a `file` (.json, .jsonl, .csv, .html, .txt, .xml, .pdf, .sarif, .md, .junit.xml; repeatable)")
+	fs.StringVar(&o.formats, "format", "", "Write report.<ext> for each format in a `list` (e.g. json,html,sarif)")
+	fs.StringVar(&o.outputDir, "output-dir", "", "`dir`ectory for the -format reports (default: current directory)")
+	fs.StringVar(&o.configFile, "config", "config.json", "Configuration `file`")
+	fs.StringVar(&o.mode, "mode", "", "Scan `mode`: 'whitelist' or 'blacklist' (overrides config)")
+	fs.StringVar(&o.extensions, "ext", "", "Extensions for the active mode (comma-separated `list`, e.g. txt,log,csv)")
+	fs.StringVar(&o.exclude, "exclude", "", "Directories to skip (comma-separated `list`, default: from config)")
+	fs.Var(&ruleFlag{rules: &o.pathRules, prefix: "+ "}, "include-path", "Include paths matching a glob or re:regex `pattern` (repeatable)")
+	fs.Var(&ruleFlag{rules: &o.pathRules, prefix: "- "}, "exclude-path", "Exclude paths matching a glob or re:regex `pattern` (repeatable)")
+	fs.StringVar(&o.ignoreFiles, "ignore-files", "", "Ignore `files` to honour in each directory (e.g. .gitignore,.panscanignore; 'none' disables)")
+	fs.BoolVar(&o.followSymlinks, "follow-symlinks", false, "Follow symlinked directories (loop-safe)")
+	fs.BoolVar(&o.xdev, "xdev", false, "Don't cross into other filesystems (mount points)")
+	fs.StringVar(&o.detect, "detect", "", "File type detection `mode`: extension, content or both")
+	fs.StringVar(&o.binDB, "bin-db", "", "BIN database JSON `file` to use instead of the built-in one")
+	fs.IntVar(&o.workers, "workers", 0, "Run `n` workers concurrently (default: CPU cores / 2)")
+
+	fs.StringVar(&o.newerThan, "newer-than", "", "Only files changed within an `age` or since a date")
+	fs.StringVar(&o.olderThan, "older-than", "", "Only files changed before an `age` or date")
+	fs.StringVar(&o.timeField, "time-field", "", "Timestamp `field` for -newer-than/-older-than: mtime or ctime")
+	fs.StringVar(&o.owner, "owner", "", "Only files owned by these users (`list` of names or uids)")
+	fs.StringVar(&o.group, "group", "", "Only files owned by these groups (`list` of names or gids)")
+	fs.StringVar(&o.minSize, "min-size", "", "Skip files smaller than this `size` (e.g. 1KB)")
+	fs.StringVar(&o.fileTimeout, "file-timeout", "", "Give up on a single file after this `duration` (e.g. 30s, 0 = no limit)")
+	fs.StringVar(&o.maxFileMemory, "max-file-memory", "", "Memory `size` allowed per file when decompressing (e.g. 256MB)")
+
+	fs.StringVar(&o.syslog, "syslog", "", "Send findings to a syslog `url`: udp://host:514, tcp://host:514, tls://host:6514, unix:///dev/log")
+	fs.StringVar(&o.syslogFormat, "syslog-format", "cef", "Syslog payload `format`: cef (ArcSight, Splunk) or leef (QRadar)")
+	fs.StringVar(&o.syslogCA, "syslog-ca", "", "CA certificate `file` (PEM) of a tls:// collector (default: system roots)")
+
+	fs.StringVar(&o.webhook, "webhook", "", "Post scan results to a webhook `url` (Slack, Teams or any HTTP endpoint)")
+	fs.StringVar(&o.webhookType, "webhook-type", "", "Webhook body `type`: generic, slack or teams (default: from the URL)")
+	fs.StringVar(&o.webhookOn, "webhook-on", "completed", "Webhook `events` to send: completed, findings or both (comma-separated)")
+	fs.StringVar(&o.webhookSecret, "webhook-secret", "", "Sign webhook deliveries with HMAC-SHA256 using this `key` (default: $PANSCAN_WEBHOOK_SECRET)")
+	fs.StringVar(&o.webhookTemplate, "webhook-template", "", "Go text/template `file` that renders the webhook body")
+	fs.IntVar(&o.webhookRetries, "webhook-retries", 3, "Retry a failed webhook delivery `n` times, with backoff")
+
+	fs.StringVar(&o.git, "git", "", "Scan every commit on every ref of a `repo`sitory (needs git)")
+	fs.StringVar(&o.s3, "s3", "", "Bucket and prefix `url`, e.g. s3://log-archive/2025/")
+	fs.StringVar(&o.s3Endpoint, "s3-endpoint", "", "S3-compatible server `url` (MinIO: http://localhost:9000)")
+	fs.StringVar(&o.s3Region, "s3-region", "", "S3 `region` (default: $AWS_REGION or us-east-1)")
+
+	return o
+}
+
+// applyTo applies the CLI overrides to the configuration
+// CLI flags take precedence over config.json
+//
+// Parameters:
+//   - cfg: Configuration to update
+//   - out: Where to report each override (io.Discard for none)
+//
+// Returns:
+//   - error: Error if an override value is invalid
+func (o *scanOptions) applyTo(cfg *config.Config, out io.Writer) error {
+	// Override scan mode if specified via CLI
+	if o.mode != "" {
+		if o.mode != "whitelist" && o.mode != "blacklist" {
+			return fmt.Errorf("invalid mode '%s', must be 'whitelist' or 'blacklist'", o.mode)
+		}
+		cfg.ScanMode = o.mode
+		fmt.Fprintf(out, "✓ Scan mode overridden via CLI: %s\n", cfg.ScanMode)
+	}
+
+	// Override extensions if specified via CLI
+	if o.extensions != "" {
+		extensions := strings.Split(o.extensions, ",")
+		// Trim whitespace from each extension
+		for i := range extensions {
+			extensions[i] = strings.TrimSpace(extensions[i])
+		}
+
+		// Apply to active scan mode
+		if cfg.ScanMode == "whitelist" {
+			cfg.WhitelistExtensions = extensions
+			fmt.Fprintf(out, "✓ Whitelist extensions overridden via CLI: %d extensions\n", len(extensions))
+		} else {
+			cfg.BlacklistExtensions = extensions
+			fmt.Fprintf(out, "✓ Blacklist extensions overridden via CLI: %d extensions\n", len(extensions))
+		}
+	}
+
+	// Override exclude directories if specified via CLI
+	if o.exclude != "" {
+		cfg.ExcludeDirs = strings.Split(o.exclude, ",")
+		// Trim whitespace from each directory name
+		for i := range cfg.ExcludeDirs {
+			cfg.ExcludeDirs[i] = strings.TrimSpace(cfg.ExcludeDirs[i])
+		}
+		fmt.Fprintf(out, "✓ Exclude directories overridden via CLI: %d directories\n", len(cfg.ExcludeDirs))
+	}
+
+	// Override file type detection if specified via CLI
+	if o.detect != "" {
+		if !config.ValidTypeDetection(o.detect) {
+			return fmt.Errorf("invalid detect mode '%s', must be 'extension', 'content' or 'both'", o.detect)
+		}
+		cfg.FileTypeDetection = o.detect
+		fmt.Fprintf(out, "✓ File type detection overridden via CLI: %s\n", cfg.FileTypeDetection)
+	}
+
+	// Override attribute filters if specified via CLI
+	if o.newerThan != "" {
+		cfg.NewerThan = o.newerThan
+	}
+	if o.olderThan != "" {
+		cfg.OlderThan = o.olderThan
+	}
+	if o.timeField != "" {
+		if o.timeField != "mtime" && o.timeField != "ctime" {
+			return fmt.Errorf("invalid time field '%s', must be 'mtime' or 'ctime'", o.timeField)
+		}
+		cfg.TimeField = o.timeField
+	}
+	if o.owner != "" {
+		cfg.Owners = splitList(o.owner)
+	}
+	if o.group != "" {
+		cfg.Groups = splitList(o.group)
+	}
+	if o.minSize != "" {
+		cfg.MinFileSize = o.minSize
+	}
+
+	// Override per-file limits if specified via CLI
+	if o.fileTimeout != "" {
+		cfg.FileTimeout = o.fileTimeout
+	}
+	if o.maxFileMemory != "" {
+		cfg.MaxFileMemory = o.maxFileMemory
+	}
+
+	// Override ignore files if specified via CLI
+	if o.ignoreFiles != "" {
+		cfg.IgnoreFiles = splitList(o.ignoreFiles)
+		if o.ignoreFiles == "none" {
+			cfg.IgnoreFiles = nil
+		}
+		fmt.Fprintf(out, "✓ Ignore files overridden via CLI: %d names\n", len(cfg.IgnoreFiles))
+	}
+
+	// Walk policy: CLI flags can only turn these on
+	if o.followSymlinks {
+		cfg.FollowSymlinks = true
+	}
+	if o.xdev {
+		cfg.OneFileSystem = true
+	}
+
+	// Path rules: config.json first, then CLI rules in command-line order
+	// (last match wins, so CLI rules override config rules)
+	if len(o.pathRules) > 0 {
+		cfg.PathRules = append(cfg.PathRules, o.pathRules...)
+		fmt.Fprintf(out, "✓ Path rules added via CLI: %d rules\n", len(o.pathRules))
+	}
+
+	if o.binDB != "" {
+		cfg.BINDatabase = o.binDB
+	}
+
+	// Normalize all extensions (add dots, convert to lowercase)
+	cfg.NormalizeExtensions()
+	return nil
+}
+
+// outputFiles returns the report files to write
+// Combines the -output files with report.<ext> in -output-dir for each
+// -format, and checks them all (extension known, no file twice)
+//
+// Returns:
+//   - []string: Files to write (empty = no report)
+//   - error: Unknown format or extension, or a duplicate file
+func (o *scanOptions) outputFiles() ([]string, error) {
+	files := append([]string(nil), o.outputs...)
+
+	if o.formats == "" {
+		if o.outputDir != "" {
+			return nil, fmt.Errorf("-output-dir needs -format")
+		}
+	} else {
+		for _, name := range splitList(o.formats) {
+			format, err := report.FormatByName(name)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, filepath.Join(o.outputDir, "report"+format.Extension))
+		}
+	}
+
+	if err := report.CheckOutputs(files); err != nil {
+		return nil, err
+	}
+	return files, nil
+}
+
+// runScan runs the scan command
+//
+// Parameters:
+//   - opts: Parsed scan options
+//   - args: Remaining arguments (extra paths to scan)
+//
+// Returns:
+//   - int: Exit code (0 no cards, 1 error, 2 cards found)
+func runScan(opts *scanOptions, args []string) int {
+	// Positional arguments are paths: ./scanner scan /var/log /srv
+	opts.paths = append(opts.paths, args...)
+
+	// ============================================================
+	// STEP 1: Check the scan source
+	// ============================================================
+	// A history (-git) or bucket (-s3) scan replaces the directory scan
+	gitScan := opts.git != ""
+	s3Scan := opts.s3 != ""
+
+	sources := 0
+	pathScan := len(opts.paths) > 0 || opts.filesFrom != ""
+	for _, set := range []bool{pathScan, gitScan, s3Scan} {
+		if set {
+			sources++
+		}
+	}
+	if sources == 0 {
+		fmt.Fprintln(os.Stderr, "Error: -path flag is required (or -files-from / -git / -s3)")
+		fmt.Fprintln(os.Stderr, "Use './scanner help scan' for usage information")
+		return 1
+	}
+	if sources > 1 {
+		fmt.Fprintln(os.Stderr, "Error: -path/-files-from, -git and -s3 cannot be combined")
+		return 1
+	}
+
+	// Check the outputs now rather than after a long scan
+	outputs, err := opts.outputFiles()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	// ============================================================
+	// STEP 2: Display application banner
+	// ============================================================
+
+	ui.ShowBanner(Version)
+
+	// ============================================================
+	// STEP 3: Load configuration from config.json
+	// ============================================================
+	// Configuration is optional - use defaults if file missing
+
+	cfg := loadConfig(opts.configFile)
+
+	// ============================================================
+	// STEP 4: Apply CLI overrides to configuration
+	// ============================================================
+
+	if err := opts.applyTo(cfg, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	// ============================================================
+	// STEP 5: Load BIN Database (CRITICAL)
+	// ============================================================
+	// This must be done BEFORE creating the scanner
+	// The scanner depends on the BIN database for card type detection
+	// The database is built into the binary; -bin-db / bin_database
+	// load an external file instead
+
+	fmt.Println("Loading BIN database...")
+
+	binDB, err := loadBINDatabase(cfg.BINDatabase)
+	if err != nil {
+		// CRITICAL ERROR: Cannot proceed without BIN database
+		fmt.Fprintf(os.Stderr, "\n✗ CRITICAL ERROR: Failed to load BIN database\n")
+		fmt.Fprintf(os.Stderr, "  Error: %v\n\n", err)
+		fmt.Fprintln(os.Stderr, "  The scanner cannot detect card types without this database.")
+		fmt.Fprintln(os.Stderr, "\n  Troubleshooting steps:")
+		fmt.Fprintln(os.Stderr, "  1. Verify the -bin-db / bin_database file exists")
+		fmt.Fprintln(os.Stderr, "  2. Check file permissions (must be readable)")
+		fmt.Fprintln(os.Stderr, "  3. Validate JSON syntax using a JSON validator")
+		fmt.Fprintln(os.Stderr, "  4. Leave -bin-db and bin_database empty to use the built-in database")
+		return 1
+	}
+
+	// Database loaded successfully - show info
+	fmt.Printf("✓ BIN Database v%s loaded successfully\n", binDB.GetVersion())
+	fmt.Printf("  Source: %s\n", binDB.GetSource())
+	fmt.Printf("  SHA-256: %s\n", binDB.GetChecksum())
+	fmt.Printf("  Last updated: %s\n", binDB.GetLastUpdated())
+	fmt.Printf("  Supporting %d card issuers\n", binDB.GetIssuerCount())
+	fmt.Println()
+
+	// ============================================================
+	// STEP 6: Determine optimal worker count
+	// ============================================================
+	// Balance between performance and resource usage
+
+	numCPU := runtime.NumCPU()
+	workers := opts.workers
+
+	// If not specified, use half of CPU cores (minimum 1)
+	if workers == 0 {
+		workers = numCPU / 2
+		if workers < 1 {
+			workers = 1
+		}
+	}
+
+	// Validate worker count
+	if workers < 1 {
+		fmt.Fprintln(os.Stderr, "Error: workers must be at least 1")
+		return 1
+	}
+
+	// Warn if worker count exceeds CPU cores
+	if workers > numCPU {
+		fmt.Printf("⚠ Warning: workers (%d) exceeds CPU cores (%d), limiting to %d\n",
+			workers, numCPU, numCPU)
+		workers = numCPU
+	}
+
+	// ============================================================
+	// STEP 7: Validate target path
+	// ============================================================
+	// Ensure the path exists and is accessible
+	// Paths listed by -files-from are checked while scanning instead:
+	// a stale entry in a long inventory list only produces a warning
+
+	var scanTarget string
+	var scanPaths []string
+
+	switch {
+	case s3Scan:
+		scanTarget = opts.s3
+	case gitScan:
+		scanTarget = opts.git
+		err = config.ValidatePath(scanTarget)
+	default:
+		for _, path := range opts.paths {
+			if err = config.ValidatePath(path); err != nil {
+				break
+			}
+		}
+		scanPaths = opts.paths
+
+		if err == nil && opts.filesFrom != "" {
+			var listed []string
+			listed, err = readFileList(opts.filesFrom)
+			if err == nil && len(listed) == 0 {
+				err = fmt.Errorf("no paths found in %s", opts.filesFrom)
+			}
+			scanPaths = append(scanPaths, listed...)
+		}
+
+		scanTarget = describePaths(scanPaths)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	// ============================================================
+	// STEP 8: Create file and directory filters
+	// ============================================================
+
+	// Extension filter (whitelist or blacklist mode)
+	extFilter := filter.NewExtensionFilter(cfg.ScanMode, cfg.WhitelistExtensions, cfg.BlacklistExtensions)
+
+	// Directory filter (always applied)
+	dirFilter := filter.NewDirectoryFilter(cfg.ExcludeDirs)
+
+	// Ignore filter (.gitignore-style files in each directory, nil if none)
+	ignoreFilter := filter.NewIgnoreFilter(cfg.IgnoreFiles)
+
+	// Path filter (glob/regex include/exclude rules, nil if none)
+	pathFilter, err := filter.NewPathFilter(cfg.PathRules)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	// ============================================================
+	// STEP 9: Parse file size limits and attribute filters
+	// ============================================================
+
+	maxFileSize, err := cfg.GetMaxFileSizeBytes()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	attrFilter, err := newAttributeFilter(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	fileTimeout, err := cfg.GetFileTimeout()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: file-timeout: %v\n", err)
+		return 1
+	}
+
+	maxFileMemory, err := cfg.GetMaxFileMemoryBytes()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: max-file-memory: %v\n", err)
+		return 1
+	}
+
+	// ============================================================
+	// STEP 10: Create scanner with configuration
+	// ============================================================
+
+	// Progress tracker for UI updates
+	progressTracker := ui.NewProgressTracker()
+
+	scannerConfig := &scanner.Config{
+		ExtFilter:    extFilter,
+		DirFilter:    dirFilter,
+		PathFilter:   pathFilter,
+		IgnoreFilter: ignoreFilter,
+		MaxFileSize:  maxFileSize,
+		AttrFilter:   attrFilter,
+		Workers:      workers,
+		// Per-file limits: a bad file is reported, not waited for
+		FileTimeout:   fileTimeout,
+		MaxFileMemory: maxFileMemory,
+		// Symlink and mount point policy
+		FollowSymlinks: cfg.FollowSymlinks,
+		OneFileSystem:  cfg.OneFileSystem,
+		// How files are selected: by extension, content or both
+		TypeDetection: cfg.FileTypeDetection,
+		// Issuer matching against the database loaded above
+		Issuers: binDB,
+		// Progress callback for real-time updates
+		// (the scanner doesn't print: unscanned files are reported here)
+		ProgressCallback: func(event scanner.ProgressEvent) {
+			if event.Type == scanner.FileErrored {
+				fmt.Printf("\rWarning: failed to scan %s: %v\n", event.Path, event.Err.Err)
+			}
+			// Walk errors come before the total is known
+			if (event.Type == scanner.FileFinished || event.Type == scanner.FileErrored) && event.Total > 0 {
+				progressTracker.Update(event.Processed, event.Total, event.CardsFound)
+			}
+		},
+	}
+
+	// .jsonl outputs, syslog and webhooks are written while the scan runs,
+	// the other outputs after it
+	streams, exports, err := openStreams(outputs, report.SyslogConfig{
+		URL:     opts.syslog,
+		Format:  opts.syslogFormat,
+		CAFile:  opts.syslogCA,
+		Version: Version,
+	}, report.WebhookConfig{
+		URL:          opts.webhook,
+		Type:         opts.webhookType,
+		Events:       splitList(opts.webhookOn),
+		Secret:       firstNonEmpty(opts.webhookSecret, os.Getenv("PANSCAN_WEBHOOK_SECRET")),
+		TemplateFile: opts.webhookTemplate,
+		Retries:      opts.webhookRetries,
+		Version:      Version,
+	}, scanTarget)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if len(streams) > 0 {
+		scannerConfig.Sink = streams
+		// Without other outputs nothing needs the findings after the scan
+		// (the webhook summary needs them for its top files)
+		scannerConfig.SinkOnly = len(exports) == 0 && opts.webhook == ""
+	}
+
+	// JUnit lists every scanned file as a test case; the other outputs
+	// don't need the paths, so they are only kept when it is requested
+	for _, output := range exports {
+		if format, err := report.FormatForFile(output); err == nil && format.Name == "junit" {
+			scannerConfig.RecordScannedPaths = true
+		}
+	}
+
+	s := scanner.NewScanner(scannerConfig)
+
+	// ============================================================
+	// STEP 11: Display scan configuration
+	// ============================================================
+
+	var extensionCount int
+	if cfg.ScanMode == "whitelist" {
+		extensionCount = len(cfg.WhitelistExtensions)
+	} else {
+		extensionCount = len(cfg.BlacklistExtensions)
+	}
+
+	maxSizeStr := config.FormatBytes(maxFileSize)
+	if maxFileSize == 0 {
+		maxSizeStr = "unlimited"
+	}
+
+	if s3Scan {
+		ui.ShowScanInfo(scanTarget, cfg.ScanMode, extensionCount, workers, maxSizeStr)
+	} else if gitScan {
+		ui.ShowScanInfo(scanTarget+" (git history, all refs)", cfg.ScanMode, extensionCount, 1, maxSizeStr)
+	} else {
+		ui.ShowScanInfo(scanTarget, cfg.ScanMode, extensionCount, workers, maxSizeStr)
+	}
+
+	// ============================================================
+	// STEP 12: Execute the scan
+	// ============================================================
+
+	var result *scanner.ScanResult
+
+	// Ctrl+C stops the scan early: the files scanned so far are
+	// still summarised and exported
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+
+	fmt.Println("Starting scan...")
+	if s3Scan {
+		// Bucket scan: objects play the role of files
+		// Credentials come from the standard AWS environment variables
+		var bucket, prefix string
+		var source *scanner.S3Source
+		bucket, prefix, err = scanner.ParseS3URL(opts.s3)
+		if err == nil {
+			source, err = scanner.NewS3Source(&scanner.S3SourceConfig{
+				Bucket:             bucket,
+				Prefix:             prefix,
+				Endpoint:           firstNonEmpty(opts.s3Endpoint, os.Getenv("AWS_ENDPOINT_URL")),
+				Region:             firstNonEmpty(opts.s3Region, os.Getenv("AWS_REGION"), os.Getenv("AWS_DEFAULT_REGION")),
+				AccessKeyID:        os.Getenv("AWS_ACCESS_KEY_ID"),
+				SecretAccessKey:    os.Getenv("AWS_SECRET_ACCESS_KEY"),
+				SessionToken:       os.Getenv("AWS_SESSION_TOKEN"),
+				ExtFilter:          extFilter,
+				PathFilter:         pathFilter,
+				MaxFileSize:        maxFileSize,
+				FileTimeout:        fileTimeout,
+				MaxFileMemory:      maxFileMemory,
+				Workers:            workers,
+				Issuers:            binDB,
+				ProgressCallback:   scannerConfig.ProgressCallback,
+				Sink:               scannerConfig.Sink,
+				SinkOnly:           scannerConfig.SinkOnly,
+				RecordScannedPaths: scannerConfig.RecordScannedPaths,
+			})
+		}
+		if err == nil {
+			result, err = source.ScanContext(ctx)
+		}
+	} else if gitScan {
+		// History scan: every unique blob plays the role of a file
+		var source *scanner.GitSource
+		source, err = scanner.NewGitSource(&scanner.GitSourceConfig{
+			RepoPath:           opts.git,
+			ExtFilter:          extFilter,
+			DirFilter:          dirFilter,
+			PathFilter:         pathFilter,
+			MaxFileSize:        maxFileSize,
+			MaxFileMemory:      maxFileMemory,
+			Issuers:            binDB,
+			ProgressCallback:   scannerConfig.ProgressCallback,
+			Sink:               scannerConfig.Sink,
+			SinkOnly:           scannerConfig.SinkOnly,
+			RecordScannedPaths: scannerConfig.RecordScannedPaths,
+		})
+		if err == nil {
+			result, err = source.ScanContext(ctx)
+		}
+	} else {
+		// Several roots (or a file list) are scanned in one pass
+		if len(scanPaths) == 1 {
+			result, err = s.ScanDirectoryContext(ctx, scanPaths[0])
+		} else {
+			result, err = s.ScanPathsContext(ctx, scanPaths)
+		}
+	}
+	if err != nil && result != nil && ctx.Err() != nil {
+		fmt.Println("\n⚠ Scan interrupted - results cover the files scanned so far")
+		err = nil
+	}
+	stop()
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "\n✗ Scan failed: %v\n", err)
+		streams.close(nil) // Keep what was streamed; no summary marks it incomplete
+		return 1
+	}
+
+	// ============================================================
+	// STEP 13: Display results
+	// ============================================================
+
+	skipCounts := []ui.SkipCount{
+		{Reason: scanner.SkipReasonPath, Count: result.SkippedByPath},
+		{Reason: scanner.SkipReasonContent, Count: result.SkippedByContent},
+		{Reason: scanner.SkipReasonAge, Count: result.SkippedByAge},
+		{Reason: scanner.SkipReasonOwner, Count: result.SkippedByOwner},
+		{Reason: scanner.SkipReasonMinSize, Count: result.SkippedByMinSize},
+		{Reason: scanner.SkipReasonIgnore, Count: result.SkippedByIgnore},
+		{Reason: scanner.SkipReasonSymlink, Count: result.SkippedSymlinks},
+		{Reason: scanner.SkipReasonSpecial, Count: result.SkippedSpecial},
+		{Reason: scanner.SkipReasonMount, Count: result.SkippedMounts},
+	}
+
+	// Files that were selected but could not be scanned, by cause
+	errorCounts := result.ErrorCounts()
+	for _, class := range scanner.ErrorClasses {
+		skipCounts = append(skipCounts, ui.SkipCount{Reason: string(class), Count: errorCounts[class], Failed: true})
+	}
+
+	ui.ShowSummary(
+		result.Duration,
+		result.TotalFiles,
+		result.ScannedFiles,
+		result.SkippedBySize,
+		result.SkippedByExt,
+		result.CardsFound,
+		result.ScanRate,
+		skipCounts...,
+	)
+
+	// ============================================================
+	// STEP 14: Export report if output file specified
+	// ============================================================
+	// Streams need the report too (JSONL summary, syslog scan-end event,
+	// webhook scan.completed)
+
+	if len(outputs) > 0 || len(streams) > 0 {
+		// Determine which extensions list to show in report
+		var reportExtensions []string
+		if cfg.ScanMode == "whitelist" {
+			reportExtensions = cfg.WhitelistExtensions
+		} else {
+			reportExtensions = cfg.BlacklistExtensions
+		}
+
+		// Missing -files-from entries are not scanned roots: label the
+		// report from the roots it lists, so Directory and Roots agree
+		if !s3Scan && !gitScan {
+			scanTarget = describePaths(result.Roots)
+		}
+
+		// Create report instance
+		rep := report.NewReport(
+			Version,
+			scanTarget,
+			cfg.ScanMode,
+			reportExtensions,
+			result,
+		)
+		rep.BINDatabase = report.BINDatabaseInfo{
+			Version:  binDB.GetVersion(),
+			Source:   binDB.GetSource(),
+			Checksum: binDB.GetChecksum(),
+		}
+
+		// Generate and save the reports (all formats at once)
+		// Format is determined automatically from file extension
+		fmt.Printf("\nGenerating report...\n")
+		failed := !streams.close(rep)
+		if err := writeReports(rep, exports); err != nil {
+			fmt.Fprintf(os.Stderr, "✗ %v\n", err)
+			failed = true
+		}
+		if failed {
+			return 1
+		}
+	}
+
+	// ============================================================
+	// STEP 15: Exit with appropriate code
+	// ============================================================
+
+	// Exit with error code if cards were found (for CI/CD integration)
+	if result.CardsFound > 0 {
+		return 2 // Exit code 2 indicates cards were found
+	}
+
+	// Success - no cards found
+	return 0
+}
+
+// ============================================================
+// STREAMED OUTPUTS
+// ============================================================
+
+// findingStream is an output written while the scan runs
+// (report.JSONLWriter, report.SyslogWriter, report.WebhookNotifier)
+type findingStream interface {
+	scanner.FindingSink
+	Name() string
+	Findings() int
+	Close(rep *report.Report) error
+}
+
+// streamOutput is an open stream and the format shown in its status line
+type streamOutput struct {
+	format string // "jsonl", "syslog" or "webhook"
+	findingStream
+}
+
+// findingStreams are the outputs written while the scan runs
+// It is the scanner's FindingSink: every file's findings go to each stream
+type findingStreams []streamOutput
+
+// openStreams opens the .jsonl outputs, the syslog collector and the webhook
+//
+// Parameters:
+//   - outputs: All output files
+//   - syslog: Syslog settings (URL "" = no syslog)
+//   - webhook: Webhook settings (URL "" = no webhook)
+//   - target: What is scanned, for the syslog scan-start event and webhooks
+//
+// Returns:
+//   - findingStreams: Open streams
+//   - []string: The other outputs (exported after the scan)
+//   - error: Error if a .jsonl file can't be created, syslog can't be
+//     reached or the webhook settings are invalid
+func openStreams(outputs []string, syslog report.SyslogConfig, webhook report.WebhookConfig, target string) (findingStreams, []string, error) {
+	var streams findingStreams
+	var exports []string
+
+	for _, output := range outputs {
+		if strings.ToLower(filepath.Ext(output)) != ".jsonl" {
+			exports = append(exports, output)
+			continue
+		}
+
+		err := os.MkdirAll(filepath.Dir(output), 0755)
+		var w *report.JSONLWriter
+		if err == nil {
+			w, err = report.NewJSONLWriter(output)
+		}
+		if err != nil {
+			streams.close(nil)
+			return nil, nil, fmt.Errorf("failed to create %s: %w", output, err)
+		}
+		streams = append(streams, streamOutput{"jsonl", w})
+	}
+
+	if syslog.URL != "" {
+		w, err := report.NewSyslogWriter(syslog)
+		if err != nil {
+			streams.close(nil)
+			return nil, nil, err
+		}
+		w.ScanStarted(target)
+		streams = append(streams, streamOutput{"syslog", w})
+	}
+
+	if webhook.URL != "" {
+		webhook.Target = target
+		n, err := report.NewWebhookNotifier(webhook)
+		if err != nil {
+			streams.close(nil)
+			return nil, nil, err
+		}
+		streams = append(streams, streamOutput{"webhook", n})
+	}
+	return streams, exports, nil
+}
+
+// WriteFindings passes a file's findings to every stream
+// (scanner.FindingSink interface)
+func (s findingStreams) WriteFindings(path string, findings []scanner.Finding) {
+	for _, stream := range s {
+		stream.WriteFindings(path, findings)
+	}
+}
+
+// close finishes every stream (summary line, scan-end event) and closes it
+// A nil report closes the streams without finishing them (scan failed)
+//
+// Returns:
+//   - bool: true if every stream was written completely
+func (s findingStreams) close(rep *report.Report) bool {
+	ok := true
+	for _, stream := range s {
+		err := stream.Close(rep)
+		switch {
+		case err != nil:
+			fmt.Fprintf(os.Stderr, "✗ %-7s %s: %v\n", stream.format, stream.Name(), err)
+			ok = false
+		case rep == nil:
+		case stream.format == "webhook":
+			fmt.Printf("✓ %-7s %s (%d notifications sent)\n", stream.format, stream.Name(),
+				stream.findingStream.(*report.WebhookNotifier).Sent())
+		default:
+			fmt.Printf("✓ %-7s %s (streamed, %d findings)\n", stream.format, stream.Name(), stream.Findings())
+		}
+	}
+	return ok
+}
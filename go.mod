@@ -0,0 +1,3 @@
+module github.com/keraattin/BasicPanScanner
+
+go 1.21
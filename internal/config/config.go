@@ -9,6 +9,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Config holds all configuration settings for the scanner
@@ -30,9 +31,68 @@ type Config struct {
 	// Example: [".git", "node_modules", "vendor"]
 	ExcludeDirs []string `json:"exclude_dirs"`
 
+	// PathRules are ordered include/exclude rules on full paths
+	// Each rule is "+ pattern" (include) or "- pattern" (exclude);
+	// patterns are gitignore-style globs or "re:" regexes. Last match wins.
+	// Example: ["- **/fixtures/*.json", "+ /var/log/**/payment*.log"]
+	PathRules []string `json:"path_rules"`
+
+	// FileTypeDetection decides how files are selected for scanning
+	// Valid values:
+	//   "extension" - whitelist/blacklist extensions only (default)
+	//   "content"   - ignore extensions, sniff content and skip binaries
+	//   "both"      - extension filter first, then skip binaries by content
+	// Readers are always chosen by content (a renamed XLSX is still read as XLSX)
+	FileTypeDetection string `json:"file_type_detection"`
+
+	// IgnoreFiles are .gitignore-style files honoured in every scanned
+	// directory, lowest priority first
+	// Example: [".gitignore", ".panscanignore"] ([] = disabled)
+	IgnoreFiles []string `json:"ignore_files"`
+
+	// FollowSymlinks walks into symlinked directories (loops are
+	// detected); false skips them. Symlinked files are always scanned
+	FollowSymlinks bool `json:"follow_symlinks"`
+
+	// OneFileSystem stays on the filesystem of each scan path (like find -xdev)
+	// Mount points below it (NFS shares, /proc, ...) are skipped
+	OneFileSystem bool `json:"one_file_system"`
+
 	// MaxFileSize is the maximum file size to scan (e.g., "50MB")
 	// Files larger than this will be skipped
 	MaxFileSize string `json:"max_file_size"`
+
+	// MinFileSize is the minimum file size to scan (e.g., "1KB")
+	// Files smaller than this will be skipped ("" = no minimum)
+	MinFileSize string `json:"min_file_size"`
+
+	// FileTimeout bounds the time spent on one file (e.g., "30s", "2m")
+	// Files that take longer are reported as unscanned ("" = no limit)
+	FileTimeout string `json:"file_timeout"`
+
+	// MaxFileMemory bounds the memory used to read one file (e.g., "256MB")
+	// Counts decompressed PDF streams, office XML and gzip data, so it
+	// stops decompression bombs that max_file_size can't see ("" = no limit)
+	MaxFileMemory string `json:"max_file_memory"`
+
+	// NewerThan / OlderThan limit the scan to files by age
+	// Either a duration back from now ("30d", "12h", "2w") or a date ("2025-01-31")
+	// Example: newer_than "30d" scans only files changed in the last 30 days
+	NewerThan string `json:"newer_than"`
+	OlderThan string `json:"older_than"`
+
+	// TimeField selects the timestamp used by newer_than/older_than
+	// Valid values: "mtime" (modification, default) or "ctime" (status change)
+	TimeField string `json:"time_field"`
+
+	// Owners / Groups limit the scan to files owned by these users/groups
+	// Names or numeric ids, e.g. ["www-data", "1001"] (Unix only)
+	Owners []string `json:"owners"`
+	Groups []string `json:"groups"`
+
+	// BINDatabase is a bin_ranges.json file to use instead of the
+	// database embedded in the binary ("" = embedded)
+	BINDatabase string `json:"bin_database"`
 }
 
 // Load reads and parses the configuration file
@@ -82,6 +142,121 @@ func Load(filename string) (*Config, error) {
 	return &cfg, nil
 }
 
+// Default returns the configuration used when no config file exists
+// Also written by "scanner config init" as a starting point
+//
+// Returns:
+//   - *Config: Blacklist mode with common binary, media and archive
+//     extensions skipped and common dev directories excluded
+func Default() *Config {
+	return &Config{
+		ScanMode:            "blacklist",
+		WhitelistExtensions: []string{},
+		BlacklistExtensions: []string{
+			".exe", ".dll", ".so", ".dylib", // Binaries
+			".jpg", ".png", ".gif", ".mp4", // Media
+			".zip", ".tar", ".gz", ".rar", // Archives
+		},
+		ExcludeDirs: []string{
+			".git", "node_modules", "vendor", // Common dev dirs
+		},
+		PathRules:         []string{},
+		FileTypeDetection: "extension",
+		IgnoreFiles:       []string{},
+		MaxFileSize:       "50MB",
+		FileTimeout:       "60s",
+		MaxFileMemory:     "512MB",
+		TimeField:         "mtime",
+		Owners:            []string{},
+		Groups:            []string{},
+	}
+}
+
+// Save writes the configuration as indented JSON
+//
+// Parameters:
+//   - filename: File to write (overwritten if it exists)
+//
+// Returns:
+//   - error: Error if encoding or writing fails
+//
+// Example:
+//
+//	err := config.Default().Save("config.json")
+func (c *Config) Save(filename string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not encode config: %w", err)
+	}
+
+	if err := os.WriteFile(filename, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("could not write config file '%s': %w", filename, err)
+	}
+	return nil
+}
+
+// GetMinFileSizeBytes converts the MinFileSize string to bytes
+//
+// Returns:
+//   - int64: Size in bytes (0 means no minimum)
+//   - error: Error if format is invalid
+func (c *Config) GetMinFileSizeBytes() (int64, error) {
+	return ParseFileSize(c.MinFileSize)
+}
+
+// GetFileTimeout parses the FileTimeout string
+//
+// Returns:
+//   - time.Duration: Per-file timeout (0 means no limit)
+//   - error: Error if format is invalid
+//
+// Example:
+//
+//	timeout, err := cfg.GetFileTimeout()
+//	"30s" returns 30 * time.Second
+func (c *Config) GetFileTimeout() (time.Duration, error) {
+	return ParseTimeout(c.FileTimeout)
+}
+
+// GetMaxFileMemoryBytes converts the MaxFileMemory string to bytes
+//
+// Returns:
+//   - int64: Size in bytes (0 means no limit)
+//   - error: Error if format is invalid
+func (c *Config) GetMaxFileMemoryBytes() (int64, error) {
+	return ParseFileSize(c.MaxFileMemory)
+}
+
+// ParseTimeout parses a timeout in time.ParseDuration format
+//
+// Parameters:
+//   - value: Duration (e.g., "30s", "1m30s"), "" or "0" for no limit
+//
+// Returns:
+//   - time.Duration: Parsed timeout (0 means no limit)
+//   - error: Error if format is invalid or negative
+//
+// Examples:
+//
+//	ParseTimeout("30s")  => 30s, nil
+//	ParseTimeout("")     => 0, nil (no limit)
+//	ParseTimeout("-5s")  => 0, error
+func ParseTimeout(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "0" {
+		return 0, nil
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration '%s' (use e.g. 30s, 2m)", value)
+	}
+	if timeout < 0 {
+		return 0, fmt.Errorf("duration '%s' must not be negative", value)
+	}
+	return timeout, nil
+}
+
 // GetMaxFileSizeBytes converts the MaxFileSize string to bytes
 // This function handles size suffixes like "MB", "GB", etc.
 //
@@ -112,13 +287,14 @@ func (c *Config) GetMaxFileSizeBytes() (int64, error) {
 //	ParseFileSize("100MB")  => 104857600, nil
 //	ParseFileSize("1GB")    => 1073741824, nil
 //	ParseFileSize("")       => 0, nil (no limit)
+//	ParseFileSize("0")      => 0, nil (no limit)
 //	ParseFileSize("100XB")  => 0, error
 func ParseFileSize(sizeStr string) (int64, error) {
 	// Normalize to uppercase and remove whitespace
 	sizeStr = strings.ToUpper(strings.TrimSpace(sizeStr))
 
-	// Empty string means no limit
-	if sizeStr == "" {
+	// Empty string (or a plain "0") means no limit
+	if sizeStr == "" || sizeStr == "0" {
 		return 0, nil
 	}
 
@@ -174,6 +350,61 @@ func ParseFileSize(sizeStr string) (int64, error) {
 	return 0, fmt.Errorf("invalid size format '%s': must end with B, KB, MB, or GB", sizeStr)
 }
 
+// ParseTimeBound converts an age or date to a point in time
+// Used for newer_than / older_than and the -newer-than / -older-than flags
+//
+// Supports:
+//   - Durations back from now with s, m, h, d (days) and w (weeks)
+//   - Dates: "2006-01-02" or RFC 3339 "2006-01-02T15:04:05Z07:00"
+//
+// Parameters:
+//   - value: Age or date (empty = no bound)
+//   - now: Reference time for durations
+//
+// Returns:
+//   - time.Time: The bound (zero time for empty value)
+//   - error: Error if format is invalid
+//
+// Examples:
+//
+//	ParseTimeBound("30d", now)        => now minus 30 days
+//	ParseTimeBound("2w", now)         => now minus 14 days
+//	ParseTimeBound("2025-01-31", now) => 2025-01-31 00:00 local time
+//	ParseTimeBound("", now)           => zero time (no bound)
+func ParseTimeBound(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	// Absolute dates
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	// Durations: add day and week units to what time.ParseDuration knows
+	units := map[byte]time.Duration{
+		'd': 24 * time.Hour,
+		'w': 7 * 24 * time.Hour,
+	}
+	if unit, ok := units[value[len(value)-1]]; ok {
+		num, err := strconv.ParseFloat(value[:len(value)-1], 64)
+		if err != nil || num < 0 {
+			return time.Time{}, fmt.Errorf("invalid age '%s': expected e.g. 30d, 12h, 2w or 2025-01-31", value)
+		}
+		return now.Add(-time.Duration(num * float64(unit))), nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return time.Time{}, fmt.Errorf("invalid age '%s': expected e.g. 30d, 12h, 2w or 2025-01-31", value)
+	}
+	return now.Add(-d), nil
+}
+
 // FormatBytes converts bytes to human-readable format
 // This is useful for displaying file sizes to users
 //
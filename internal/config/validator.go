@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 )
 
 // Validate checks if the configuration is valid and usable
@@ -16,7 +17,8 @@ import (
 //   - Appropriate extension lists are populated
 //   - No duplicate extensions
 //   - No conflicts between whitelist and blacklist
-//   - Valid max file size format
+//   - Valid max/min file size format
+//   - Valid newer_than/older_than ages and time_field
 //
 // Returns:
 //   - error: Descriptive error if validation fails, nil if valid
@@ -113,6 +115,48 @@ func Validate(cfg *Config) error {
 		}
 	}
 
+	if cfg.MinFileSize != "" {
+		if _, err := ParseFileSize(cfg.MinFileSize); err != nil {
+			return fmt.Errorf("config error: invalid min_file_size '%s': %v", cfg.MinFileSize, err)
+		}
+	}
+
+	// ============================================================
+	// PER-FILE LIMITS VALIDATION
+	// ============================================================
+
+	if _, err := ParseTimeout(cfg.FileTimeout); err != nil {
+		return fmt.Errorf("config error: invalid file_timeout: %v", err)
+	}
+
+	if cfg.MaxFileMemory != "" {
+		if _, err := ParseFileSize(cfg.MaxFileMemory); err != nil {
+			return fmt.Errorf("config error: invalid max_file_memory '%s': %v", cfg.MaxFileMemory, err)
+		}
+	}
+
+	// ============================================================
+	// AGE FILTER VALIDATION
+	// ============================================================
+
+	for field, value := range map[string]string{"newer_than": cfg.NewerThan, "older_than": cfg.OlderThan} {
+		if _, err := ParseTimeBound(value, time.Now()); err != nil {
+			return fmt.Errorf("config error: invalid %s: %v", field, err)
+		}
+	}
+
+	if cfg.TimeField != "" && cfg.TimeField != "mtime" && cfg.TimeField != "ctime" {
+		return fmt.Errorf("config error: time_field must be 'mtime' or 'ctime', got '%s'", cfg.TimeField)
+	}
+
+	// ============================================================
+	// FILE TYPE DETECTION VALIDATION
+	// ============================================================
+
+	if !ValidTypeDetection(cfg.FileTypeDetection) {
+		return fmt.Errorf("config error: file_type_detection must be 'extension', 'content' or 'both', got '%s'", cfg.FileTypeDetection)
+	}
+
 	// ============================================================
 	// EXCLUDE DIRECTORIES VALIDATION
 	// ============================================================
@@ -233,14 +277,25 @@ func findConflicts(list1, list2 []string) []string {
 	return conflicts
 }
 
-// ValidatePath checks if a directory path exists and is accessible
+// ValidTypeDetection reports whether mode is a valid file_type_detection
+// value ("" means the default, "extension")
+func ValidTypeDetection(mode string) bool {
+	switch mode {
+	case "", "extension", "content", "both":
+		return true
+	}
+	return false
+}
+
+// ValidatePath checks if a scan path exists and is accessible
 // This is used to validate the scan path before starting
 //
 // Parameters:
-//   - path: Directory path to validate
+//   - path: Directory or regular file to validate
 //
 // Returns:
-//   - error: Error if path doesn't exist or isn't a directory
+//   - error: Error if path doesn't exist or is neither a directory
+//     nor a regular file (device, socket, ...)
 //
 // Example:
 //
@@ -260,9 +315,9 @@ func ValidatePath(path string) error {
 		return fmt.Errorf("error accessing path: %w", err)
 	}
 
-	// Check if it's actually a directory
-	if !info.IsDir() {
-		return fmt.Errorf("path is not a directory: %s", path)
+	// Directories are walked, single files are scanned directly
+	if !info.IsDir() && !info.Mode().IsRegular() {
+		return fmt.Errorf("path is not a directory or regular file: %s", path)
 	}
 
 	return nil
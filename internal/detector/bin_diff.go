@@ -0,0 +1,152 @@
+// Package detector - BIN database diff
+// File: internal/detector/bin_diff.go
+//
+// DiffBINFiles compares two versions of bin_ranges.json issuer by issuer,
+// so a reviewer can see what a vendor import or a hand edit changed
+// before the new file is deployed.
+package detector
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Change kinds
+const (
+	BINChangeAdded    = "+"
+	BINChangeRemoved  = "-"
+	BINChangeModified = "~"
+)
+
+// BINChange is one difference between two BIN database files
+type BINChange struct {
+	Kind    string // BINChangeAdded, BINChangeRemoved or BINChangeModified
+	Issuer  string // Issuer the change is about ("" = file metadata)
+	Message string
+}
+
+// String formats the change for display: "+ Visa: range 400000-499999"
+func (c BINChange) String() string {
+	if c.Issuer == "" {
+		return fmt.Sprintf("%s %s", c.Kind, c.Message)
+	}
+	return fmt.Sprintf("%s %s: %s", c.Kind, c.Issuer, c.Message)
+}
+
+// DiffBINFiles lists the differences between two BIN database files
+//
+// Issuers are matched by name (case-insensitive). For issuers in both
+// files the priority, lengths, active flag, region and individual ranges
+// are compared.
+//
+// Parameters:
+//   - oldFile: Previous version
+//   - newFile: New version
+//
+// Returns:
+//   - []BINChange: Differences (metadata first, then issuers by name)
+//
+// Example:
+//
+//	for _, change := range DiffBINFiles(oldFile, newFile) {
+//	    fmt.Println(change) // "~ Visa: priority 55 → 60"
+//	}
+func DiffBINFiles(oldFile, newFile *BINFile) []BINChange {
+	var changes []BINChange
+
+	if oldFile.Version != newFile.Version {
+		changes = append(changes, BINChange{BINChangeModified, "", fmt.Sprintf("version %s → %s", oldFile.Version, newFile.Version)})
+	}
+	if oldFile.LastUpdated != newFile.LastUpdated {
+		changes = append(changes, BINChange{BINChangeModified, "", fmt.Sprintf("last_updated %s → %s", oldFile.LastUpdated, newFile.LastUpdated)})
+	}
+
+	oldIssuers := issuersByName(oldFile.Issuers)
+	newIssuers := issuersByName(newFile.Issuers)
+
+	var names []string
+	for name := range oldIssuers {
+		names = append(names, name)
+	}
+	for name := range newIssuers {
+		if _, ok := oldIssuers[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, key := range names {
+		before, inOld := oldIssuers[key]
+		after, inNew := newIssuers[key]
+
+		switch {
+		case !inOld:
+			changes = append(changes, BINChange{BINChangeAdded, after.Issuer,
+				fmt.Sprintf("new issuer (priority %d, lengths %v, %d ranges)", after.Priority, after.Lengths, len(after.Ranges))})
+		case !inNew:
+			changes = append(changes, BINChange{BINChangeRemoved, before.Issuer, "issuer removed"})
+		default:
+			changes = append(changes, diffIssuer(before, after)...)
+		}
+	}
+
+	return changes
+}
+
+// diffIssuer compares two versions of one issuer
+func diffIssuer(before, after BINIssuer) []BINChange {
+	var changes []BINChange
+	modified := func(format string, args ...interface{}) {
+		changes = append(changes, BINChange{BINChangeModified, after.Issuer, fmt.Sprintf(format, args...)})
+	}
+
+	if before.Priority != after.Priority {
+		modified("priority %d → %d", before.Priority, after.Priority)
+	}
+	if fmt.Sprint(before.Lengths) != fmt.Sprint(after.Lengths) {
+		modified("lengths %v → %v", before.Lengths, after.Lengths)
+	}
+	if before.Active != after.Active {
+		modified("active %t → %t", before.Active, after.Active)
+	}
+	if before.Region != after.Region {
+		modified("region %q → %q", before.Region, after.Region)
+	}
+
+	oldRanges := rangeSet(before.Ranges)
+	newRanges := rangeSet(after.Ranges)
+	for _, r := range after.Ranges {
+		if key := r.Start + "-" + r.End; !oldRanges[key] {
+			changes = append(changes, BINChange{BINChangeAdded, after.Issuer, "range " + key})
+		}
+	}
+	for _, r := range before.Ranges {
+		if key := r.Start + "-" + r.End; !newRanges[key] {
+			changes = append(changes, BINChange{BINChangeRemoved, after.Issuer, "range " + key})
+		}
+	}
+
+	return changes
+}
+
+// issuersByName indexes issuers by lower-case name (first definition wins)
+func issuersByName(issuers []BINIssuer) map[string]BINIssuer {
+	byName := make(map[string]BINIssuer)
+	for _, issuer := range issuers {
+		key := strings.ToLower(issuer.Issuer)
+		if _, ok := byName[key]; !ok {
+			byName[key] = issuer
+		}
+	}
+	return byName
+}
+
+// rangeSet returns the ranges as a set of "start-end" strings
+func rangeSet(ranges []BINRange) map[string]bool {
+	set := make(map[string]bool)
+	for _, r := range ranges {
+		set[r.Start+"-"+r.End] = true
+	}
+	return set
+}
@@ -0,0 +1,129 @@
+// Package detector - BIN database files
+// File: internal/detector/bin_file.go
+//
+// BINDatabaseLoader turns bin_ranges.json into a lookup structure and
+// rejects files it can't use. The maintenance tools (bindb validate,
+// merge, diff) need the file as written instead - including the broken
+// parts - and must write it back without losing the metadata. BINFile is
+// that raw view:
+//
+//	f, err := ReadBINFile("bin_ranges.json")
+//	issues := f.Validate()       // bin_validate.go
+//	f.MergeCSV(feed, defaults)   // bin_merge.go
+//	changes := DiffBINFiles(old, f) // bin_diff.go
+//	data, err := f.Encode()
+package detector
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// BINFile is a bin_ranges.json file as written
+// Nothing is validated or sorted, so Issuers keeps the file order
+type BINFile struct {
+	// Version and LastUpdated come from the "_info" block
+	Version     string
+	LastUpdated string
+
+	// Issuers is the "bin_ranges" list
+	Issuers []BINIssuer
+
+	// fields keeps every top-level key ("_comment", "_info", ...) so
+	// Encode writes them back unchanged
+	fields map[string]json.RawMessage
+}
+
+// ReadBINFile reads a bin_ranges.json file
+//
+// Parameters:
+//   - path: JSON file to read
+//
+// Returns:
+//   - *BINFile: File content
+//   - error: Error if the file can't be read or isn't valid JSON
+func ReadBINFile(path string) (*BINFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read BIN database file '%s': %w", path, err)
+	}
+	return ParseBINFile(data)
+}
+
+// ParseBINFile parses bin_ranges.json content
+// Only JSON syntax and field types are checked (see Validate for the rest)
+//
+// Parameters:
+//   - data: JSON content
+//
+// Returns:
+//   - *BINFile: File content
+//   - error: Error if the JSON is invalid
+func ParseBINFile(data []byte) (*BINFile, error) {
+	f := &BINFile{}
+	if err := json.Unmarshal(data, &f.fields); err != nil {
+		return nil, fmt.Errorf("failed to parse BIN database JSON: %w", err)
+	}
+
+	var parsed jsonStructure
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse BIN database JSON: %w", err)
+	}
+
+	f.Version = parsed.Info.Version
+	f.LastUpdated = parsed.Info.LastUpdated
+	f.Issuers = parsed.BINRanges
+	return f, nil
+}
+
+// EmbeddedBINFile returns the database built into the binary as a BINFile
+func EmbeddedBINFile() (*BINFile, error) {
+	return ParseBINFile(embeddedBINData)
+}
+
+// Encode writes the file back as indented JSON
+// Version and LastUpdated are stored in "_info" (its other keys are kept)
+//
+// Returns:
+//   - []byte: JSON content (2-space indent, trailing newline)
+//   - error: Encoding error
+func (f *BINFile) Encode() ([]byte, error) {
+	info := map[string]json.RawMessage{}
+	if raw, ok := f.fields["_info"]; ok {
+		if err := json.Unmarshal(raw, &info); err != nil {
+			return nil, fmt.Errorf("invalid _info block: %w", err)
+		}
+	}
+
+	for key, value := range map[string]string{"version": f.Version, "last_updated": f.LastUpdated} {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		info[key] = encoded
+	}
+
+	fields := map[string]interface{}{}
+	for key, value := range f.fields {
+		fields[key] = value
+	}
+	fields["_info"] = info
+	fields["bin_ranges"] = f.Issuers
+
+	data, err := json.MarshalIndent(fields, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode BIN database: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// Database builds the lookup structure from the file
+// Same checks as BINDatabaseLoader.Parse
+func (f *BINFile) Database() (*BINDatabase, error) {
+	data, err := f.Encode()
+	if err != nil {
+		return nil, err
+	}
+	return NewBINDatabaseLoader().Parse(data)
+}
@@ -35,6 +35,8 @@
 package detector
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -47,10 +49,14 @@ import (
 // CONSTANTS
 // ============================================================
 
-// DefaultBINDatabasePath is the default location for the BIN database file
-// Relative to the project root directory
+// DefaultBINDatabasePath is where the bundled BIN database lives in the
+// source tree (relative to the project root). The file is compiled into
+// the binary (see embedded.go), so nothing is read from this path at run time
 const DefaultBINDatabasePath = "internal/detector/bindata/bin_ranges.json"
 
+// EmbeddedSource is the Source of the database compiled into the binary
+const EmbeddedSource = "embedded"
+
 // ============================================================
 // DATA STRUCTURES
 // ============================================================
@@ -150,6 +156,14 @@ type BINDatabase struct {
 	// Sorted by priority (highest first) for overlap resolution
 	Issuers []BINIssuer
 
+	// Source is where the database came from: the file path given to
+	// Load(), EmbeddedSource, or "" for Parse() (set by the caller)
+	Source string
+
+	// Checksum is the hex SHA-256 of the JSON the database was built from
+	// Recorded in reports so a scan can be tied to an exact dataset
+	Checksum string
+
 	// sortedRanges contains all BIN ranges in sorted order
 	// Used for binary search optimization
 	// Created during Load() operation
@@ -249,12 +263,45 @@ func (l *BINDatabaseLoader) Load(filePath string) (*BINDatabase, error) {
 		return nil, fmt.Errorf("BIN database file '%s' is empty", filePath)
 	}
 
+	db, err := l.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+	db.Source = filePath
+	return db, nil
+}
+
+// Parse builds a BIN database from JSON content already in memory
+//
+// Same format and validation as Load (steps 2-5), without touching the
+// disk - for databases fetched from elsewhere, built in tests, or
+// compiled into the binary.
+//
+// Parameters:
+//   - data: Content of a bin_ranges.json file
+//
+// Returns:
+//   - *BINDatabase: Loaded and ready database
+//   - error: Error if the JSON is invalid or validation fails
+//
+// Example:
+//
+//	db, err := NewBINDatabaseLoader().Parse([]byte(`{
+//	    "_info": {"version": "test"},
+//	    "bin_ranges": [{"issuer": "Visa", "priority": 55, "active": true,
+//	                    "lengths": [16], "ranges": [{"start": "400000", "end": "499999"}]}]
+//	}`))
+func (l *BINDatabaseLoader) Parse(data []byte) (*BINDatabase, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("BIN database is empty")
+	}
+
 	// ============================================================
 	// STEP 2: Parse JSON
 	// ============================================================
 
 	var jsonData jsonStructure
-	err = json.Unmarshal(data, &jsonData)
+	err := json.Unmarshal(data, &jsonData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse BIN database JSON: %w", err)
 	}
@@ -268,10 +315,12 @@ func (l *BINDatabaseLoader) Load(filePath string) (*BINDatabase, error) {
 	// STEP 3: Create database structure
 	// ============================================================
 
+	checksum := sha256.Sum256(data)
 	db := &BINDatabase{
 		Version:     jsonData.Info.Version,
 		LastUpdated: jsonData.Info.LastUpdated,
 		Issuers:     jsonData.BINRanges,
+		Checksum:    hex.EncodeToString(checksum[:]),
 	}
 
 	// Validate we have at least some issuers
@@ -545,6 +594,17 @@ func (db *BINDatabase) GetLastUpdated() string {
 	return db.LastUpdated
 }
 
+// GetSource returns where the database was loaded from
+// (a file path or EmbeddedSource)
+func (db *BINDatabase) GetSource() string {
+	return db.Source
+}
+
+// GetChecksum returns the hex SHA-256 of the database JSON
+func (db *BINDatabase) GetChecksum() string {
+	return db.Checksum
+}
+
 // GetIssuerCount returns the number of active issuers
 func (db *BINDatabase) GetIssuerCount() int {
 	count := 0
@@ -590,16 +650,17 @@ var (
 
 // InitGlobalBINDatabase initializes the global BIN database
 //
-// This function MUST be called once at application startup
-// before any card detection operations.
+// The global database is a convenience for callers that don't pass a
+// database explicitly (MatchIssuer, DetectCardsInFile with nil). Call this
+// at startup to load it from a custom path; otherwise the embedded
+// database is used on first use.
 //
 // Thread-safety: Uses sync.Once to ensure single initialization
 // even if called from multiple goroutines concurrently.
 //
 // Parameters:
 //   - path: Path to the BIN database JSON file
-//     Pass empty string "" to use default path
-//     Default: "internal/detector/bindata/bin_ranges.json"
+//     Pass empty string "" to use the database embedded in the binary
 //
 // Returns:
 //   - error: Error if database fails to load
@@ -607,22 +668,23 @@ var (
 // Example usage in main.go:
 //
 //	func main() {
-//	    // Initialize BIN database (use default path)
+//	    // Initialize BIN database (embedded default)
 //	    err := detector.InitGlobalBINDatabase("")
 //	    if err != nil {
 //	        log.Fatalf("Fatal: Failed to initialize BIN database: %v", err)
 //	    }
 //
-//	    // Now MatchIssuer() can be used anywhere in the app
+//	    // MatchIssuer() and nil resolvers now use this database
 //	    // ...
 //	}
 func InitGlobalBINDatabase(path string) error {
 	// Use sync.Once to ensure initialization happens exactly once
 	// Even if called from multiple goroutines concurrently
 	dbOnce.Do(func() {
-		// Use default path if not specified
+		// Use the embedded database if no path is specified
 		if path == "" {
-			path = DefaultBINDatabasePath
+			globalBINDatabase, dbInitError = LoadEmbeddedBINDatabase()
+			return
 		}
 
 		// Create loader and load database
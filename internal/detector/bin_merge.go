@@ -0,0 +1,295 @@
+// Package detector - Vendor feed import
+// File: internal/detector/bin_merge.go
+//
+// Vendor BIN feeds come as CSV, one BIN or BIN range per row. MergeCSV
+// folds such a feed into a BINFile: ranges are added to the issuer named
+// in the row (created if unknown), and each issuer's ranges are sorted and
+// joined so repeated imports don't pile up duplicates. Issuers the feed
+// doesn't mention are left exactly as they are.
+//
+// Recognised columns (header row, case-insensitive, any order):
+//
+//	issuer | brand | scheme          Issuer name (required)
+//	bin | start | bin_start         First BIN (required)
+//	end | bin_end                   Last BIN (default: same as start)
+//	lengths | length | pan_length   Card lengths, e.g. "16" or "16|19"
+//	                                (added to the issuer's lengths)
+//	priority, region, display_name  Only used for new issuers
+//
+// 8-digit BINs are cut to their first 6 digits (the lookup uses 6), which
+// widens the range; MergeStats.Widened counts those rows.
+package detector
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MergeDefaults holds the values used for issuers a feed introduces
+type MergeDefaults struct {
+	Priority int   // Priority of new issuers (default 50)
+	Lengths  []int // Card lengths when the row has none (default [16])
+}
+
+// MergeStats summarises what MergeCSV changed
+type MergeStats struct {
+	Rows       int      // Data rows read
+	Added      int      // Ranges not already covered by their issuer
+	Duplicates int      // Ranges the issuer already covered
+	Widened    int      // 8-digit BINs cut to 6 digits
+	NewIssuers []string // Issuers created for the feed
+}
+
+// csvColumns maps accepted header names to a field
+var csvColumns = map[string]string{
+	"issuer": "issuer", "brand": "issuer", "scheme": "issuer",
+	"bin": "start", "start": "start", "bin_start": "start",
+	"end": "end", "bin_end": "end",
+	"lengths": "lengths", "length": "lengths", "pan_length": "lengths",
+	"priority": "priority", "region": "region", "display_name": "display_name",
+}
+
+// MergeCSV adds the BIN ranges of a vendor CSV feed to the file
+//
+// Parameters:
+//   - r: CSV feed with a header row (see the package comment above)
+//   - defaults: Values for issuers not yet in the file
+//
+// Returns:
+//   - MergeStats: What was added
+//   - error: Error if the CSV is malformed (the file is left unchanged)
+//
+// Example:
+//
+//	feed, _ := os.Open("vendor_bins.csv")
+//	stats, err := f.MergeCSV(feed, MergeDefaults{Priority: 40})
+//	fmt.Printf("%d ranges added, %d already present\n", stats.Added, stats.Duplicates)
+func (f *BINFile) MergeCSV(r io.Reader, defaults MergeDefaults) (MergeStats, error) {
+	var stats MergeStats
+	if defaults.Priority == 0 {
+		defaults.Priority = 50
+	}
+	if len(defaults.Lengths) == 0 {
+		defaults.Lengths = []int{16}
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return stats, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	columns := make(map[string]int)
+	for i, name := range header {
+		if field, ok := csvColumns[strings.ToLower(strings.TrimSpace(name))]; ok {
+			columns[field] = i
+		}
+	}
+	if _, ok := columns["issuer"]; !ok {
+		return stats, fmt.Errorf("CSV header has no issuer column (issuer, brand or scheme)")
+	}
+	if _, ok := columns["start"]; !ok {
+		return stats, fmt.Errorf("CSV header has no BIN column (bin, start or bin_start)")
+	}
+
+	// Work on a copy so a bad row leaves the file untouched
+	issuers := make([]BINIssuer, len(f.Issuers))
+	copy(issuers, f.Issuers)
+	touched := make(map[int]bool)
+	byName := make(map[string]int)
+	for i, issuer := range issuers {
+		byName[strings.ToLower(issuer.Issuer)] = i
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return MergeStats{}, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		stats.Rows++
+		line, _ := reader.FieldPos(0)
+
+		row := func(field string) string {
+			if i, ok := columns[field]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		name := row("issuer")
+		if name == "" {
+			return MergeStats{}, fmt.Errorf("CSV line %d: issuer is empty", line)
+		}
+
+		start, end := row("start"), row("end")
+		if end == "" {
+			end = start
+		}
+		var widened bool
+		start, end, widened, err = normalizeFeedRange(start, end)
+		if err != nil {
+			return MergeStats{}, fmt.Errorf("CSV line %d: %w", line, err)
+		}
+		if widened {
+			stats.Widened++
+		}
+
+		lengths, err := parseFeedLengths(row("lengths"))
+		if err != nil {
+			return MergeStats{}, fmt.Errorf("CSV line %d: %w", line, err)
+		}
+
+		index, exists := byName[strings.ToLower(name)]
+		if !exists {
+			priority := defaults.Priority
+			if value := row("priority"); value != "" {
+				if priority, err = strconv.Atoi(value); err != nil {
+					return MergeStats{}, fmt.Errorf("CSV line %d: invalid priority '%s'", line, value)
+				}
+			}
+			issuers = append(issuers, BINIssuer{
+				Issuer:      name,
+				DisplayName: row("display_name"),
+				Lengths:     append([]int(nil), defaults.Lengths...),
+				Priority:    priority,
+				Region:      row("region"),
+				Active:      true,
+				Notes:       "Imported from vendor feed",
+			})
+			index = len(issuers) - 1
+			byName[strings.ToLower(name)] = index
+			stats.NewIssuers = append(stats.NewIssuers, name)
+		}
+
+		issuer := &issuers[index]
+		touched[index] = true
+		if lengths != nil {
+			if !exists && len(issuer.Ranges) == 0 {
+				issuer.Lengths = nil
+			}
+			issuer.Lengths = mergeLengths(issuer.Lengths, lengths)
+		}
+
+		if rangeCovered(issuer.Ranges, start, end) {
+			stats.Duplicates++
+			continue
+		}
+		issuer.Ranges = append(issuer.Ranges, BINRange{Start: start, End: end})
+		stats.Added++
+	}
+
+	for i := range touched {
+		issuers[i].Ranges = joinRanges(issuers[i].Ranges)
+	}
+	f.Issuers = issuers
+	return stats, nil
+}
+
+// normalizeFeedRange checks a feed range and cuts 8-digit BINs to 6 digits
+func normalizeFeedRange(start, end string) (string, string, bool, error) {
+	for _, bin := range []string{start, end} {
+		if _, err := strconv.Atoi(bin); err != nil || strings.HasPrefix(bin, "-") {
+			return "", "", false, fmt.Errorf("BIN '%s' is not numeric", bin)
+		}
+		if len(bin) != 6 && len(bin) != 8 {
+			return "", "", false, fmt.Errorf("BIN '%s' must have 6 or 8 digits", bin)
+		}
+	}
+
+	widened := false
+	if len(start) == 8 {
+		start, widened = start[:6], true
+	}
+	if len(end) == 8 {
+		end, widened = end[:6], true
+	}
+	if start > end {
+		return "", "", false, fmt.Errorf("BIN range %s-%s starts after it ends", start, end)
+	}
+	return start, end, widened, nil
+}
+
+// parseFeedLengths parses "16", "16|19" or "13,16,19" (nil if empty)
+func parseFeedLengths(value string) ([]int, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	var lengths []int
+	for _, part := range strings.FieldsFunc(value, func(r rune) bool { return r == '|' || r == ',' || r == ';' || r == ' ' }) {
+		length, err := strconv.Atoi(part)
+		if err != nil || length < 12 || length > 19 {
+			return nil, fmt.Errorf("invalid card length '%s'", part)
+		}
+		lengths = append(lengths, length)
+	}
+	return lengths, nil
+}
+
+// mergeLengths returns the sorted union of two length lists
+func mergeLengths(a, b []int) []int {
+	seen := make(map[int]bool)
+	var merged []int
+	for _, length := range append(append([]int(nil), a...), b...) {
+		if !seen[length] {
+			seen[length] = true
+			merged = append(merged, length)
+		}
+	}
+	sort.Ints(merged)
+	return merged
+}
+
+// rangeCovered reports whether start-end lies inside one of the ranges
+func rangeCovered(ranges []BINRange, start, end string) bool {
+	for _, r := range ranges {
+		if r.Start <= start && end <= r.End && len(r.Start) == len(start) && len(r.End) == len(end) {
+			return true
+		}
+	}
+	return false
+}
+
+// joinRanges sorts 6-digit ranges and joins overlapping or adjacent ones
+// Ranges in any other format are kept as they are, after the others
+func joinRanges(ranges []BINRange) []BINRange {
+	var spans []binSpan
+	var others []BINRange
+	for _, r := range ranges {
+		if span, ok := parseBINRange(r); ok {
+			spans = append(spans, span)
+		} else {
+			others = append(others, r)
+		}
+	}
+	if len(spans) == 0 {
+		return ranges
+	}
+
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start < spans[j].start })
+
+	joined := []binSpan{spans[0]}
+	for _, span := range spans[1:] {
+		last := &joined[len(joined)-1]
+		if span.start <= last.end+1 {
+			last.end = max(last.end, span.end)
+			continue
+		}
+		joined = append(joined, span)
+	}
+
+	result := make([]BINRange, 0, len(joined)+len(others))
+	for _, span := range joined {
+		result = append(result, BINRange{Start: fmt.Sprintf("%06d", span.start), End: fmt.Sprintf("%06d", span.end)})
+	}
+	return append(result, others...)
+}
@@ -0,0 +1,380 @@
+// Package detector - BIN database validation
+// File: internal/detector/bin_validate.go
+//
+// LookupBIN skips what it can't use without a word: unparsable Start/End
+// values, issuers without a matching length, ranges behind a
+// higher-priority issuer. Validate reports those problems, and Overlaps
+// explains which issuer actually wins where ranges overlap:
+//
+//	Errors    the entry is ignored or ambiguous (Load fails, or the result
+//	          depends on sort order)
+//	Warnings  the entry works but is probably not what was meant
+package detector
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Issue severities
+const (
+	BINIssueError   = "error"
+	BINIssueWarning = "warning"
+)
+
+// BINIssue is one problem found by Validate
+type BINIssue struct {
+	Severity string // BINIssueError or BINIssueWarning
+	Issuer   string // Issuer the problem is about ("" = whole file)
+	Message  string
+}
+
+// String formats the issue for display: "error: Visa: ..."
+func (i BINIssue) String() string {
+	if i.Issuer == "" {
+		return fmt.Sprintf("%s: %s", i.Severity, i.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", i.Severity, i.Issuer, i.Message)
+}
+
+// BINOverlap is a BIN segment claimed by more than one active issuer
+type BINOverlap struct {
+	Start, End string         // Segment (6-digit BINs, inclusive)
+	Issuers    []string       // Issuers covering it, highest priority first
+	Winners    map[int]string // Card length → issuer LookupBIN returns
+	Tied       bool           // Two covering issuers share a priority and a length
+}
+
+// binSpan is a parsed, usable range of an issuer
+type binSpan struct {
+	start, end int
+	issuer     int // Index in BINFile.Issuers
+}
+
+// ============================================================
+// VALIDATION
+// ============================================================
+
+// Validate checks the file for problems LookupBIN would silently skip
+//
+// Checks:
+//   - _info version present, at least one issuer
+//   - Issuer names present and unique, priority within 0-100
+//   - Lengths present, within 13-19 (what the detector considers), unique
+//   - Ranges numeric, 6 digits (8-digit BINs are not supported), start <= end,
+//     in ascending order, not overlapping the issuer's own ranges
+//   - Overlaps between issuers of the same priority (ambiguous winner)
+//   - Active issuers that never win a lookup (fully shadowed)
+//
+// Returns:
+//   - []BINIssue: Problems found, errors and warnings mixed in file order
+//
+// Example:
+//
+//	for _, issue := range f.Validate() {
+//	    fmt.Println(issue)
+//	}
+func (f *BINFile) Validate() []BINIssue {
+	var issues []BINIssue
+	add := func(severity, issuer, format string, args ...interface{}) {
+		issues = append(issues, BINIssue{Severity: severity, Issuer: issuer, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if f.Version == "" {
+		add(BINIssueError, "", "_info.version is missing (the database will not load)")
+	}
+	if len(f.Issuers) == 0 {
+		add(BINIssueError, "", "bin_ranges is empty")
+	}
+
+	seenNames := make(map[string]bool)
+	for _, issuer := range f.Issuers {
+		name := issuer.Issuer
+		if name == "" {
+			name = "(unnamed)"
+			add(BINIssueError, name, "issuer name is missing")
+		} else if seenNames[strings.ToLower(name)] {
+			add(BINIssueWarning, name, "issuer is defined more than once")
+		}
+		seenNames[strings.ToLower(name)] = true
+
+		if issuer.Priority < 0 || issuer.Priority > 100 {
+			add(BINIssueWarning, name, "priority %d is outside 0-100", issuer.Priority)
+		}
+
+		issues = append(issues, validateLengths(name, issuer.Lengths)...)
+		issues = append(issues, validateRanges(name, issuer)...)
+	}
+
+	// Overlap checks only make sense on ranges the lookup can use
+	for _, overlap := range f.Overlaps() {
+		if overlap.Tied {
+			add(BINIssueError, "", "%s-%s is claimed by %s with the same priority (winner depends on sort order)",
+				overlap.Start, overlap.End, strings.Join(overlap.Issuers, ", "))
+		}
+	}
+
+	wins := f.winningIssuers()
+	for i, issuer := range f.Issuers {
+		if issuer.Active && len(issuer.Ranges) > 0 && len(issuer.Lengths) > 0 && !wins[i] && f.hasUsableRange(i) {
+			add(BINIssueWarning, issuer.Issuer, "never wins a lookup (every range is shadowed by higher-priority issuers)")
+		}
+	}
+
+	return issues
+}
+
+// validateLengths checks an issuer's card lengths
+func validateLengths(name string, lengths []int) []BINIssue {
+	var issues []BINIssue
+	if len(lengths) == 0 {
+		return append(issues, BINIssue{BINIssueError, name, "no card lengths (no card can match)"})
+	}
+
+	seen := make(map[int]bool)
+	for _, length := range lengths {
+		if seen[length] {
+			issues = append(issues, BINIssue{BINIssueWarning, name, fmt.Sprintf("length %d is listed twice", length)})
+		}
+		seen[length] = true
+
+		if length < 13 || length > 19 {
+			issues = append(issues, BINIssue{BINIssueWarning, name,
+				fmt.Sprintf("length %d is never matched (the detector only considers 13-19 digits)", length)})
+		}
+	}
+	return issues
+}
+
+// validateRanges checks an issuer's BIN ranges
+func validateRanges(name string, issuer BINIssuer) []BINIssue {
+	var issues []BINIssue
+	add := func(severity, format string, args ...interface{}) {
+		issues = append(issues, BINIssue{severity, name, fmt.Sprintf(format, args...)})
+	}
+
+	if len(issuer.Ranges) == 0 && issuer.Active {
+		add(BINIssueWarning, "active issuer has no ranges")
+	}
+
+	var spans []binSpan
+	ordered := true
+	for _, r := range issuer.Ranges {
+		label := r.Start + "-" + r.End
+
+		start, startErr := strconv.Atoi(r.Start)
+		end, endErr := strconv.Atoi(r.End)
+		switch {
+		case startErr != nil || endErr != nil || strings.HasPrefix(r.Start, "-") || strings.HasPrefix(r.End, "-"):
+			add(BINIssueError, "range %s is not numeric (skipped by lookup)", label)
+			continue
+		case len(r.Start) == 8 && len(r.End) == 8:
+			add(BINIssueError, "range %s uses 8-digit BINs; lookup uses 6 digits (use %s-%s)",
+				label, r.Start[:6], r.End[:6])
+			continue
+		case len(r.Start) != 6 || len(r.End) != 6:
+			add(BINIssueError, "range %s must use 6-digit BINs", label)
+			continue
+		case start > end:
+			add(BINIssueError, "range %s starts after it ends (never matches)", label)
+			continue
+		}
+
+		for _, other := range spans {
+			if start <= other.end && end >= other.start {
+				add(BINIssueWarning, "range %s overlaps its own range %06d-%06d", label, other.start, other.end)
+			}
+		}
+		if len(spans) > 0 && start < spans[len(spans)-1].start {
+			ordered = false
+		}
+		spans = append(spans, binSpan{start: start, end: end})
+	}
+
+	if !ordered {
+		add(BINIssueWarning, "ranges are not in ascending order")
+	}
+	return issues
+}
+
+// ============================================================
+// OVERLAP ANALYSIS
+// ============================================================
+
+// Overlaps lists the BIN segments claimed by more than one active issuer
+// and which issuer LookupBIN returns there for each card length
+//
+// Segments are split where coverage changes and joined again when
+// neighbouring segments have the same issuers, so the output follows the
+// ranges in the file rather than individual BINs.
+//
+// Returns:
+//   - []BINOverlap: Overlapping segments in BIN order
+//
+// Example:
+//
+//	for _, o := range f.Overlaps() {
+//	    fmt.Printf("%s-%s %v -> %v\n", o.Start, o.End, o.Issuers, o.Winners)
+//	}
+func (f *BINFile) Overlaps() []BINOverlap {
+	var overlaps []BINOverlap
+
+	for _, segment := range f.segments() {
+		if len(segment.issuers) < 2 {
+			continue
+		}
+
+		overlap := BINOverlap{
+			Start:   fmt.Sprintf("%06d", segment.start),
+			End:     fmt.Sprintf("%06d", segment.end),
+			Winners: make(map[int]string),
+		}
+		for _, i := range segment.issuers {
+			overlap.Issuers = append(overlap.Issuers, f.Issuers[i].Issuer)
+		}
+		for length, winners := range f.winnersByLength(segment.issuers) {
+			overlap.Winners[length] = f.Issuers[winners[0]].Issuer
+			if len(winners) > 1 {
+				overlap.Tied = true
+			}
+		}
+		overlaps = append(overlaps, overlap)
+	}
+
+	return overlaps
+}
+
+// binSegment is a stretch of BINs covered by the same issuers
+type binSegment struct {
+	start, end int
+	issuers    []int // Indexes in BINFile.Issuers, highest priority first
+}
+
+// segments splits the BIN space covered by active issuers into stretches
+// with constant coverage
+func (f *BINFile) segments() []binSegment {
+	spans := f.usableSpans()
+	if len(spans) == 0 {
+		return nil
+	}
+
+	// Every start and every end+1 is a point where coverage may change
+	pointSet := make(map[int]bool)
+	for _, span := range spans {
+		pointSet[span.start] = true
+		pointSet[span.end+1] = true
+	}
+	points := make([]int, 0, len(pointSet))
+	for point := range pointSet {
+		points = append(points, point)
+	}
+	sort.Ints(points)
+
+	var segments []binSegment
+	for p := 0; p+1 < len(points); p++ {
+		start, end := points[p], points[p+1]-1
+
+		covering := make(map[int]bool)
+		for _, span := range spans {
+			if span.start <= start && span.end >= end {
+				covering[span.issuer] = true
+			}
+		}
+		if len(covering) == 0 {
+			continue
+		}
+
+		issuers := make([]int, 0, len(covering))
+		for i := range covering {
+			issuers = append(issuers, i)
+		}
+		sort.Slice(issuers, func(a, b int) bool {
+			if f.Issuers[issuers[a]].Priority != f.Issuers[issuers[b]].Priority {
+				return f.Issuers[issuers[a]].Priority > f.Issuers[issuers[b]].Priority
+			}
+			return issuers[a] < issuers[b]
+		})
+
+		// Join with the previous segment if it is adjacent with the same issuers
+		if n := len(segments); n > 0 && segments[n-1].end+1 == start && slices.Equal(segments[n-1].issuers, issuers) {
+			segments[n-1].end = end
+			continue
+		}
+		segments = append(segments, binSegment{start: start, end: end, issuers: issuers})
+	}
+
+	return segments
+}
+
+// usableSpans returns the ranges of active issuers that LookupBIN can use
+func (f *BINFile) usableSpans() []binSpan {
+	var spans []binSpan
+	for i, issuer := range f.Issuers {
+		if !issuer.Active {
+			continue
+		}
+		for _, r := range issuer.Ranges {
+			if span, ok := parseBINRange(r); ok {
+				span.issuer = i
+				spans = append(spans, span)
+			}
+		}
+	}
+	return spans
+}
+
+// hasUsableRange reports whether an issuer has any range LookupBIN can use
+func (f *BINFile) hasUsableRange(issuer int) bool {
+	for _, r := range f.Issuers[issuer].Ranges {
+		if _, ok := parseBINRange(r); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// winnersByLength returns, for each card length supported in a segment,
+// the highest-priority issuers supporting it (more than one = tie)
+func (f *BINFile) winnersByLength(issuers []int) map[int][]int {
+	winners := make(map[int][]int)
+	for _, i := range issuers {
+		for _, length := range f.Issuers[i].Lengths {
+			current := winners[length]
+			if len(current) == 0 {
+				winners[length] = []int{i}
+			} else if f.Issuers[current[0]].Priority == f.Issuers[i].Priority && !slices.Contains(current, i) {
+				winners[length] = append(current, i)
+			}
+		}
+	}
+	return winners
+}
+
+// winningIssuers returns the issuers that win at least one (segment, length)
+func (f *BINFile) winningIssuers() map[int]bool {
+	wins := make(map[int]bool)
+	for _, segment := range f.segments() {
+		for _, winners := range f.winnersByLength(segment.issuers) {
+			for _, i := range winners {
+				wins[i] = true
+			}
+		}
+	}
+	return wins
+}
+
+// parseBINRange converts a range to numbers if LookupBIN can use it
+func parseBINRange(r BINRange) (binSpan, bool) {
+	if len(r.Start) != 6 || len(r.End) != 6 || strings.HasPrefix(r.Start, "-") || strings.HasPrefix(r.End, "-") {
+		return binSpan{}, false
+	}
+	start, err1 := strconv.Atoi(r.Start)
+	end, err2 := strconv.Atoi(r.End)
+	if err1 != nil || err2 != nil || start > end {
+		return binSpan{}, false
+	}
+	return binSpan{start: start, end: end}, true
+}
@@ -44,7 +44,8 @@ package detector
 func FindCardsInText(text string) map[string]string {
 	// Use the NEW pipeline detection system
 	// This handles everything: patterns, issuer matching, Luhn validation
-	cardLocations := DetectCardsInFile(text)
+	// (nil resolver = global BIN database)
+	cardLocations := DetectCardsInFile(text, nil)
 
 	// Convert CardLocation[] to map[string]string for old API compatibility
 	result := make(map[string]string)
@@ -0,0 +1,39 @@
+// Package detector - Embedded BIN database
+// File: internal/detector/embedded.go
+//
+// The bundled bindata/bin_ranges.json is compiled into the binary, so the
+// scanner works from any working directory. An external file can still be
+// loaded with BINDatabaseLoader.Load() to override it (-bin-db).
+package detector
+
+import (
+	_ "embed"
+	"fmt"
+)
+
+// embeddedBINData is the bundled BIN database (bindata/bin_ranges.json)
+//
+//go:embed bindata/bin_ranges.json
+var embeddedBINData []byte
+
+// LoadEmbeddedBINDatabase builds the BIN database compiled into the binary
+//
+// Returns:
+//   - *BINDatabase: Database with Source set to EmbeddedSource
+//   - error: Only if the bundled JSON is invalid (a build problem)
+//
+// Example:
+//
+//	db, err := LoadEmbeddedBINDatabase()
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("BIN database v%s (%s)\n", db.GetVersion(), db.GetChecksum())
+func LoadEmbeddedBINDatabase() (*BINDatabase, error) {
+	db, err := NewBINDatabaseLoader().Parse(embeddedBINData)
+	if err != nil {
+		return nil, fmt.Errorf("embedded BIN database is invalid: %w", err)
+	}
+	db.Source = EmbeddedSource
+	return db, nil
+}
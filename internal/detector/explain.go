@@ -0,0 +1,266 @@
+// Package detector - Lookup explanations
+// File: internal/detector/explain.go
+//
+// When an analyst questions a finding ("why Discover and not UnionPay?"),
+// Explain replays the detection of a single number and records every
+// decision: the BIN ranges that contain it, which of them LookupBIN
+// picks and why, and the pipeline stage that accepts or rejects it.
+//
+// Input may be:
+//   - A full PAN:   "6011000990139424", "6011-0009-9013-9424"
+//   - A masked PAN: "601100******9424" (length known, Luhn unknown)
+//   - A BIN:        "601100" or "60110099" (6-8 digits, length unknown)
+package detector
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Stage results
+const (
+	StagePass = "pass"
+	StageFail = "fail"
+	StageSkip = "skip" // Stage can't run on this input (masked PAN, BIN only)
+)
+
+// Explanation is the result of Explain for one number
+type Explanation struct {
+	// Input is the value as given
+	Input string
+
+	// Digits is the input without separators ("" for masked input)
+	Digits string
+
+	// BIN is the first 6 digits
+	BIN string
+
+	// Length is the card length (0 = BIN only, length unknown)
+	Length int
+
+	// Masked is true if some digits were hidden (*, x or #)
+	Masked bool
+
+	// Issuer is what LookupBIN returns for BIN and Length ("" = no match)
+	Issuer string
+
+	// Candidates lists every range containing the BIN, in lookup order
+	Candidates []BINCandidate
+
+	// Stages is the detection pipeline, in order
+	Stages []StageResult
+
+	// Accepted is true if the pipeline would report this number
+	Accepted bool
+}
+
+// BINCandidate is one BIN range containing the looked-up BIN
+type BINCandidate struct {
+	Issuer   string
+	Priority int
+	Range    BINRange
+	Lengths  []int
+	Active   bool
+
+	// LengthOK is true if the issuer supports the card length
+	// (always true when the length is unknown)
+	LengthOK bool
+
+	// Winner marks the range LookupBIN returns
+	Winner bool
+}
+
+// StageResult is the outcome of one pipeline stage
+type StageResult struct {
+	Name   string // "format", "issuer" or "luhn"
+	Status string // StagePass, StageFail or StageSkip
+	Detail string
+}
+
+// Explain replays the detection pipeline for a single PAN or BIN
+//
+// The stages are the ones DetectCardsInFile runs:
+//  1. format: FindCardLikePatterns must find the whole number
+//  2. issuer: MatchIssuer (BIN range + card length)
+//  3. luhn:   ValidateLuhn
+//
+// Parameters:
+//   - input: PAN, masked PAN or BIN (see the file comment above)
+//
+// Returns:
+//   - *Explanation: What the detector decides and why
+//   - error: Error if the input isn't a PAN or BIN
+//
+// Example:
+//
+//	exp, err := db.Explain("6011000990139424")
+//	fmt.Println(exp.Issuer, exp.Accepted) // Discover true
+//	for _, c := range exp.Candidates {
+//	    fmt.Println(c.Issuer, c.Priority, c.Winner)
+//	}
+func (db *BINDatabase) Explain(input string) (*Explanation, error) {
+	exp := &Explanation{Input: input}
+
+	// ============================================================
+	// STEP 1: Parse the input
+	// ============================================================
+	var digits strings.Builder
+	prefix := 0 // Digits before the first masked position
+	length := 0
+	for _, char := range strings.TrimSpace(input) {
+		switch {
+		case char >= '0' && char <= '9':
+			digits.WriteRune(char)
+			if !exp.Masked {
+				prefix++
+			}
+			length++
+		case char == '*' || char == 'x' || char == 'X' || char == '#':
+			exp.Masked = true
+			length++
+		case char == ' ' || char == '-' || char == '_':
+			// Separators accepted by the format patterns
+		default:
+			return nil, fmt.Errorf("'%s': unexpected character %q", input, char)
+		}
+	}
+
+	if prefix < 6 {
+		return nil, fmt.Errorf("'%s': need at least 6 leading digits (the BIN)", input)
+	}
+	exp.BIN = digits.String()[:6]
+
+	switch {
+	case exp.Masked:
+		exp.Length = length
+	case length <= 8:
+		// BIN only: length unknown
+	default:
+		exp.Digits = digits.String()
+		exp.Length = length
+	}
+
+	// ============================================================
+	// STEP 2: Candidate ranges (same order as LookupBIN)
+	// ============================================================
+	exp.Issuer, _ = db.LookupBIN(exp.BIN, exp.Length)
+
+	bin, _ := strconv.Atoi(exp.BIN)
+	winnerMarked := false
+	for _, issuer := range db.Issuers {
+		for _, r := range issuer.Ranges {
+			start, err1 := strconv.Atoi(r.Start)
+			end, err2 := strconv.Atoi(r.End)
+			if err1 != nil || err2 != nil || bin < start || bin > end {
+				continue
+			}
+
+			candidate := BINCandidate{
+				Issuer:   issuer.Issuer,
+				Priority: issuer.Priority,
+				Range:    r,
+				Lengths:  issuer.Lengths,
+				Active:   issuer.Active,
+				LengthOK: exp.Length == 0 || slices.Contains(issuer.Lengths, exp.Length),
+			}
+			if !winnerMarked && candidate.Active && candidate.LengthOK && issuer.Issuer == exp.Issuer {
+				candidate.Winner = true
+				winnerMarked = true
+			}
+			exp.Candidates = append(exp.Candidates, candidate)
+		}
+	}
+
+	// ============================================================
+	// STEP 3: Pipeline stages
+	// ============================================================
+	exp.Stages = []StageResult{
+		explainFormat(input, exp),
+		db.explainIssuer(exp),
+		explainLuhn(exp),
+	}
+
+	exp.Accepted = true
+	for _, stage := range exp.Stages {
+		if stage.Status != StagePass {
+			exp.Accepted = false
+		}
+	}
+	return exp, nil
+}
+
+// explainFormat runs stage 1 (FindCardLikePatterns)
+func explainFormat(input string, exp *Explanation) StageResult {
+	stage := StageResult{Name: "format"}
+	if exp.Digits == "" {
+		stage.Status = StageSkip
+		stage.Detail = "needs the full number"
+		return stage
+	}
+
+	for _, pattern := range FindCardLikePatterns(strings.TrimSpace(input)) {
+		if pattern.Normalized == exp.Digits {
+			stage.Status = StagePass
+			stage.Detail = fmt.Sprintf("matches the %d-digit pattern", exp.Length)
+			return stage
+		}
+	}
+
+	stage.Status = StageFail
+	stage.Detail = fmt.Sprintf("no card pattern matches %d digits in this grouping", exp.Length)
+	return stage
+}
+
+// explainIssuer runs stage 2 (MatchIssuer)
+func (db *BINDatabase) explainIssuer(exp *Explanation) StageResult {
+	stage := StageResult{Name: "issuer"}
+
+	var issuer string
+	var ok bool
+	switch {
+	case exp.Digits != "":
+		issuer, ok = db.MatchIssuer(exp.Digits)
+	case exp.Length != 0 && (exp.Length < 13 || exp.Length > 19):
+		// Same length check as MatchIssuer
+	default:
+		issuer, ok = exp.Issuer, exp.Issuer != ""
+	}
+
+	switch {
+	case ok:
+		stage.Status = StagePass
+		stage.Detail = issuer
+	case exp.Length != 0 && (exp.Length < 13 || exp.Length > 19):
+		stage.Status = StageFail
+		stage.Detail = fmt.Sprintf("length %d is outside 13-19", exp.Length)
+	case len(exp.Candidates) > 0 && exp.Length != 0:
+		stage.Status = StageFail
+		stage.Detail = fmt.Sprintf("no active issuer for BIN %s supports length %d", exp.BIN, exp.Length)
+	case len(exp.Candidates) > 0:
+		stage.Status = StageFail
+		stage.Detail = fmt.Sprintf("only inactive issuers cover BIN %s", exp.BIN)
+	default:
+		stage.Status = StageFail
+		stage.Detail = fmt.Sprintf("BIN %s is not in the database", exp.BIN)
+	}
+	return stage
+}
+
+// explainLuhn runs stage 3 (ValidateLuhn)
+func explainLuhn(exp *Explanation) StageResult {
+	stage := StageResult{Name: "luhn"}
+	switch {
+	case exp.Digits == "":
+		stage.Status = StageSkip
+		stage.Detail = "needs the full number"
+	case ValidateLuhn(exp.Digits):
+		stage.Status = StagePass
+		stage.Detail = "checksum valid"
+	default:
+		stage.Status = StageFail
+		stage.Detail = "checksum invalid"
+	}
+	return stage
+}
@@ -36,22 +36,25 @@
 //	✅ Discover (622126-622925) vs UnionPay (62xxxx): Discover priority 70 > UnionPay priority 60
 //	✅ Mir (2200-2204) vs Mastercard (2221-2720): Mir priority 75 > Mastercard priority 60
 //
-// CRITICAL REQUIREMENT:
+// INJECTABLE DATABASES:
 //
-//	The BIN database MUST be initialized before any card detection.
-//	If the database is not initialized, the application will panic.
-//	This is intentional - the application cannot function without it.
+//	The detection pipeline takes an IssuerResolver explicitly
+//	(DetectCardsInFile, scanner.Config.Issuers), so several BIN
+//	databases can be used side by side and tests can pass an in-memory
+//	database (BINDatabaseLoader.Parse) or a stub resolver.
+//
+//	The global database is only a convenience for callers that pass
+//	nil: the embedded database is used unless InitGlobalBINDatabase()
+//	loaded another one earlier.
 //
 // USAGE:
 //
-//	// Initialize once at application startup (in main.go)
-//	err := InitGlobalBINDatabase("")
+//	// Load a database and use it explicitly
+//	db, err := NewBINDatabaseLoader().Load("bin_ranges.json")
 //	if err != nil {
-//	    log.Fatal("Failed to initialize BIN database:", err)
+//	    log.Fatal("Failed to load BIN database:", err)
 //	}
-//
-//	// Use anywhere in the application
-//	issuer, found := MatchIssuer("4532015112830366")
+//	issuer, found := db.MatchIssuer("4532015112830366")
 //	if found {
 //	    fmt.Printf("Card type: %s\n", issuer) // Output: Visa
 //	}
@@ -64,25 +67,47 @@ import (
 	"fmt"
 )
 
+// ============================================================
+// ISSUER RESOLVER
+// ============================================================
+
+// IssuerResolver identifies the issuer of a normalized card number
+//
+// *BINDatabase is the standard implementation. Tests and embedders can
+// supply their own (a fixed map, a remote BIN service, ...).
+//
+// Example:
+//
+//	type visaOnly struct{}
+//
+//	func (visaOnly) MatchIssuer(n string) (string, bool) {
+//	    return "Visa", strings.HasPrefix(n, "4")
+//	}
+//
+//	cards := DetectCardsInFile(content, visaOnly{})
+type IssuerResolver interface {
+	// MatchIssuer returns the issuer name and true if the card is known
+	MatchIssuer(normalized string) (string, bool)
+}
+
 // ============================================================
 // MAIN ISSUER MATCHING FUNCTION
 // ============================================================
 
-// MatchIssuer determines the card issuer from a normalized card number
+// MatchIssuer determines the card issuer using the global BIN database
 //
-// This is the PRIMARY FUNCTION for issuer detection in the entire application.
+// Convenience wrapper around (*BINDatabase).MatchIssuer for callers that
+// don't carry a database of their own.
 //
 // REQUIREMENTS:
-//   - BIN database MUST be initialized before calling this function
-//   - If database is not initialized, this function will panic
-//   - This is intentional: the application cannot work without the database
+//   - The embedded database is used on first call if
+//     InitGlobalBINDatabase() wasn't called
+//   - If InitGlobalBINDatabase() failed, this function panics
+//     (a scan without issuer matching would silently find nothing)
 //
 // ALGORITHM:
-//  1. Validate input (length check)
-//  2. Get global BIN database (panic if not initialized)
-//  3. Extract first 6 digits (BIN)
-//  4. Query database with BIN and card length
-//  5. Return issuer name if found
+//  1. Get global BIN database (load the default if needed)
+//  2. Delegate to (*BINDatabase).MatchIssuer
 //
 // Parameters:
 //   - normalized: Card number with digits only (no spaces, dashes, etc.)
@@ -117,8 +142,47 @@ import (
 //   - This function does NOT perform Luhn validation
 //   - Luhn validation is done separately in PHASE 3 (pipeline_detector.go)
 //   - This separation allows early filtering of non-card numbers
-//   - BIN database must be initialized or this function will panic
+//   - New code should pass a *BINDatabase around instead of relying on
+//     the global one
 func MatchIssuer(normalized string) (string, bool) {
+	// Load the embedded database unless one was initialized already
+	// (sync.Once in InitGlobalBINDatabase makes this cheap after the first call)
+	// InitGlobalBINDatabase is defined in bin_lookup.go
+	if err := InitGlobalBINDatabase(""); err != nil {
+		// CRITICAL ERROR: no database at all
+		// We panic here because detection cannot work without the database
+		panic(fmt.Sprintf("CRITICAL: BIN database unavailable - load one and pass it explicitly: %v", err))
+	}
+
+	db, _ := GetGlobalBINDatabase()
+	return db.MatchIssuer(normalized)
+}
+
+// MatchIssuer determines the card issuer using this database instance
+//
+// Same matching as the package-level MatchIssuer, without touching the
+// global database. Use it when several databases are loaded side by side
+// or when the detector is embedded in another program.
+//
+// ALGORITHM:
+//  1. Validate input (length check)
+//  2. Extract first 6 digits (BIN)
+//  3. Query database with BIN and card length
+//  4. Return issuer name if found
+//
+// Parameters:
+//   - normalized: Card number with digits only (13-19 digits)
+//
+// Returns:
+//   - string: Issuer name ("Visa", "MasterCard", "Amex", ...)
+//   - bool: true if issuer identified, false if unknown
+//
+// Example:
+//
+//	db, _ := NewBINDatabaseLoader().Load("bin_ranges.json")
+//	issuer, found := db.MatchIssuer("4532015112830366")
+//	// issuer = "Visa", found = true
+func (db *BINDatabase) MatchIssuer(normalized string) (string, bool) {
 	// ============================================================
 	// STEP 1: Input validation
 	// ============================================================
@@ -140,22 +204,7 @@ func MatchIssuer(normalized string) (string, bool) {
 	}
 
 	// ============================================================
-	// STEP 2: Get global BIN database
-	// ============================================================
-
-	// Retrieve the global database instance
-	// This MUST succeed - if it doesn't, the application is misconfigured
-	// GetGlobalBINDatabase is defined in bin_lookup.go
-	db, err := GetGlobalBINDatabase()
-	if err != nil {
-		// CRITICAL ERROR: BIN database not initialized
-		// This should NEVER happen if main.go properly initializes the database
-		// We panic here because the application cannot function without the database
-		panic(fmt.Sprintf("CRITICAL: BIN database not initialized - call InitGlobalBINDatabase() in main(): %v", err))
-	}
-
-	// ============================================================
-	// STEP 3: Extract BIN (first 6 digits)
+	// STEP 2: Extract BIN (first 6 digits)
 	// ============================================================
 
 	// Extract the Bank Identification Number
@@ -164,7 +213,7 @@ func MatchIssuer(normalized string) (string, bool) {
 	bin := normalized[:6]
 
 	// ============================================================
-	// STEP 4: Query BIN database
+	// STEP 3: Query BIN database
 	// ============================================================
 
 	// Perform lookup with both BIN and card length
@@ -175,7 +224,7 @@ func MatchIssuer(normalized string) (string, bool) {
 	issuer, found := db.LookupBIN(bin, cardLength)
 
 	// ============================================================
-	// STEP 5: Return result
+	// STEP 4: Return result
 	// ============================================================
 
 	if found {
@@ -69,21 +69,30 @@ type CardLocation struct {
 //
 // Parameters:
 //   - content: Complete file content as string
+//   - issuers: Resolver used in Stage 3, usually a *BINDatabase
+//     (nil = the global database, see MatchIssuer)
 //
 // Returns:
 //   - []CardLocation: All valid cards found with line numbers
 //
 // Example:
 //
+//	db, _ := NewBINDatabaseLoader().Load("bin_ranges.json")
 //	content, _ := os.ReadFile("logfile.txt")
-//	cards := DetectCardsInFile(string(content))
+//	cards := DetectCardsInFile(string(content), db)
 //	for _, card := range cards {
 //	    fmt.Printf("Found %s on line %d: %s\n",
 //	        card.CardType, card.LineNumber, card.CardNumber)
 //	}
-func DetectCardsInFile(content string) []CardLocation {
+func DetectCardsInFile(content string, issuers IssuerResolver) []CardLocation {
 	var results []CardLocation
 
+	// Fall back to the global database (convenience for old callers)
+	matchIssuer := MatchIssuer
+	if issuers != nil {
+		matchIssuer = issuers.MatchIssuer
+	}
+
 	// ============================================================
 	// STAGE 1: Find card-like patterns
 	// ============================================================
@@ -145,7 +154,7 @@ func DetectCardsInFile(content string) []CardLocation {
 		//   - Random digit sequences
 		//   - ID numbers, tracking codes
 		//   - Account numbers
-		issuer, ok := MatchIssuer(pattern.Normalized)
+		issuer, ok := matchIssuer(pattern.Normalized)
 
 		// If no issuer matches, this isn't a valid card format
 		if !ok {
@@ -262,6 +271,8 @@ func findLineNumber(content string, index int) int {
 // Returns:
 //   - map[string]string: Card number → issuer name
 //
+// Uses the global BIN database (see MatchIssuer)
+//
 // Example:
 //
 //	cards := DetectCardsInFileAsMap(content)
@@ -269,7 +280,7 @@ func findLineNumber(content string, index int) int {
 //	    fmt.Printf("%s: %s\n", issuer, cardNum)
 //	}
 func DetectCardsInFileAsMap(content string) map[string]string {
-	locations := DetectCardsInFile(content)
+	locations := DetectCardsInFile(content, nil)
 
 	// Convert to map format
 	// WARNING: This loses duplicate location information!
@@ -0,0 +1,201 @@
+// Package filter - File attribute filter (age, owner, minimum size)
+// File: internal/filter/attribute_filter.go
+//
+// AttributeFilter selects files by metadata, next to ExtensionFilter
+// (by name) and DirectoryFilter (by directory):
+//   - Age: modification time (mtime) or status change time (ctime)
+//     inside a range, e.g. "changed in the last 30 days"
+//   - Owner: file owned by one of the given users and/or groups
+//   - Size: at least N bytes (the maximum is handled by the scanner)
+//
+// Ownership and ctime come from the operating system's stat data and
+// are only available on Unix-like systems (see attribute_unix.go and
+// the ctime_*.go files).
+package filter
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"strconv"
+	"time"
+)
+
+// SkipReason tells which attribute rejected a file
+type SkipReason string
+
+const (
+	SkipNone    SkipReason = ""         // File passes the filter
+	SkipAge     SkipReason = "age"      // Outside the time range
+	SkipOwner   SkipReason = "owner"    // Not owned by a selected user/group
+	SkipMinSize SkipReason = "min size" // Smaller than the minimum size
+)
+
+// AttributeFilter filters files by time, ownership and minimum size
+// The zero value (or nil) accepts every file
+type AttributeFilter struct {
+	// NewerThan: file time must be after this (zero = no lower bound)
+	NewerThan time.Time
+
+	// OlderThan: file time must be before this (zero = no upper bound)
+	OlderThan time.Time
+
+	// UseCtime checks the status change time instead of the modification time
+	UseCtime bool
+
+	// MinSize: files smaller than this many bytes are skipped (0 = no minimum)
+	MinSize int64
+
+	// Owner filters (empty = any); a file passes if its owner is in
+	// UIDs (when set) and its group is in GIDs (when set)
+	UIDs map[uint32]bool
+	GIDs map[uint32]bool
+}
+
+// NewAttributeFilter creates an attribute filter
+//
+// Parameters:
+//   - newerThan, olderThan: Time range (zero values = unbounded)
+//   - useCtime: Compare ctime instead of mtime
+//   - minSize: Minimum file size in bytes (0 = no minimum)
+//   - owners: User names or numeric uids (empty = any owner)
+//   - groups: Group names or numeric gids (empty = any group)
+//
+// Returns:
+//   - *AttributeFilter: Configured filter (nil if nothing is filtered)
+//   - error: Error if a user/group doesn't exist, or ownership/ctime
+//     filters are requested on a system without that information
+//
+// Example:
+//
+//	// Files changed in the last 30 days, owned by the "payments" account
+//	af, err := NewAttributeFilter(time.Now().AddDate(0, 0, -30), time.Time{},
+//	    false, 0, []string{"payments"}, nil)
+func NewAttributeFilter(newerThan, olderThan time.Time, useCtime bool, minSize int64, owners, groups []string) (*AttributeFilter, error) {
+	if newerThan.IsZero() && olderThan.IsZero() && minSize <= 0 && len(owners) == 0 && len(groups) == 0 {
+		return nil, nil
+	}
+
+	if (len(owners) > 0 || len(groups) > 0) && !ownershipSupported {
+		return nil, fmt.Errorf("owner/group filters are not supported on this operating system")
+	}
+	if useCtime && !ctimeSupported {
+		return nil, fmt.Errorf("ctime filters are not supported on this operating system")
+	}
+
+	af := &AttributeFilter{
+		NewerThan: newerThan,
+		OlderThan: olderThan,
+		UseCtime:  useCtime,
+		MinSize:   minSize,
+	}
+
+	if len(owners) > 0 {
+		af.UIDs = make(map[uint32]bool)
+		for _, owner := range owners {
+			uid, err := lookupID(owner, false)
+			if err != nil {
+				return nil, err
+			}
+			af.UIDs[uid] = true
+		}
+	}
+
+	if len(groups) > 0 {
+		af.GIDs = make(map[uint32]bool)
+		for _, group := range groups {
+			gid, err := lookupID(group, true)
+			if err != nil {
+				return nil, err
+			}
+			af.GIDs[gid] = true
+		}
+	}
+
+	return af, nil
+}
+
+// Check decides whether a file passes the filter
+//
+// Parameters:
+//   - info: File information from os.Stat / filepath.Walk
+//
+// Returns:
+//   - SkipReason: SkipNone if the file should be scanned,
+//     otherwise the first attribute that rejected it
+//
+// Example:
+//
+//	if reason := af.Check(info); reason != filter.SkipNone {
+//	    skipped[reason]++
+//	}
+func (af *AttributeFilter) Check(info os.FileInfo) SkipReason {
+	if af == nil {
+		return SkipNone
+	}
+
+	// Minimum size (cheapest check first)
+	if af.MinSize > 0 && info.Size() < af.MinSize {
+		return SkipMinSize
+	}
+
+	// Time range
+	if !af.NewerThan.IsZero() || !af.OlderThan.IsZero() {
+		fileTime := info.ModTime()
+		if af.UseCtime {
+			if ctime, ok := fileCtime(info); ok {
+				fileTime = ctime
+			}
+		}
+		if !af.NewerThan.IsZero() && !fileTime.After(af.NewerThan) {
+			return SkipAge
+		}
+		if !af.OlderThan.IsZero() && !fileTime.Before(af.OlderThan) {
+			return SkipAge
+		}
+	}
+
+	// Ownership
+	if len(af.UIDs) > 0 || len(af.GIDs) > 0 {
+		uid, gid, ok := fileOwner(info)
+		if !ok {
+			return SkipOwner
+		}
+		if len(af.UIDs) > 0 && !af.UIDs[uid] {
+			return SkipOwner
+		}
+		if len(af.GIDs) > 0 && !af.GIDs[gid] {
+			return SkipOwner
+		}
+	}
+
+	return SkipNone
+}
+
+// lookupID resolves a user or group name (or numeric id) to its id
+func lookupID(name string, group bool) (uint32, error) {
+	if id, err := strconv.ParseUint(name, 10, 32); err == nil {
+		return uint32(id), nil
+	}
+
+	var idStr string
+	if group {
+		g, err := user.LookupGroup(name)
+		if err != nil {
+			return 0, fmt.Errorf("unknown group '%s': %w", name, err)
+		}
+		idStr = g.Gid
+	} else {
+		u, err := user.Lookup(name)
+		if err != nil {
+			return 0, fmt.Errorf("unknown user '%s': %w", name, err)
+		}
+		idStr = u.Uid
+	}
+
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("'%s' has a non-numeric id '%s'", name, idStr)
+	}
+	return uint32(id), nil
+}
@@ -0,0 +1,17 @@
+//go:build !unix
+
+// Package filter - File attributes on systems without Unix stat data
+// File: internal/filter/attribute_other.go
+package filter
+
+import (
+	"os"
+)
+
+// ownershipSupported is false: no uid/gid available
+const ownershipSupported = false
+
+// fileOwner is not available on this operating system
+func fileOwner(info os.FileInfo) (uid, gid uint32, ok bool) {
+	return 0, 0, false
+}
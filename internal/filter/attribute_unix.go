@@ -0,0 +1,22 @@
+//go:build unix
+
+// Package filter - File attributes from Unix stat data
+// File: internal/filter/attribute_unix.go
+package filter
+
+import (
+	"os"
+	"syscall"
+)
+
+// ownershipSupported is true where stat data has uid/gid
+const ownershipSupported = true
+
+// fileOwner returns the uid and gid of a file
+func fileOwner(info os.FileInfo) (uid, gid uint32, ok bool) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, 0, false
+	}
+	return stat.Uid, stat.Gid, true
+}
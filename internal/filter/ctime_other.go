@@ -0,0 +1,18 @@
+//go:build !(linux || openbsd || solaris || illumos || darwin || freebsd || netbsd || dragonfly)
+
+// Package filter - ctime on systems without it in stat data
+// File: internal/filter/ctime_other.go
+package filter
+
+import (
+	"os"
+	"time"
+)
+
+// ctimeSupported is false: no status change time available
+const ctimeSupported = false
+
+// fileCtime is not available on this operating system
+func fileCtime(info os.FileInfo) (time.Time, bool) {
+	return time.Time{}, false
+}
@@ -0,0 +1,24 @@
+//go:build linux || openbsd || solaris || illumos
+
+// Package filter - ctime from stat data (Ctim field)
+// File: internal/filter/ctime_timespec.go
+package filter
+
+import (
+	"os"
+	"syscall"
+	"time"
+)
+
+// ctimeSupported is true where stat data has a status change time
+const ctimeSupported = true
+
+// fileCtime returns the status change time of a file
+func fileCtime(info os.FileInfo) (time.Time, bool) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return time.Time{}, false
+	}
+	sec, nsec := stat.Ctim.Unix()
+	return time.Unix(sec, nsec), true
+}
@@ -240,13 +240,16 @@ func (ef *ExtensionFilter) IsBlacklisted(ext string) bool {
 // DirectoryFilter handles directory exclusion logic
 // This is separate from extension filtering
 type DirectoryFilter struct {
-	excludeDirs map[string]bool // Directories to skip
+	excludeDirs  map[string]bool // Directory names to skip (any depth)
+	excludePaths map[string]bool // Absolute directory paths to skip (entries containing "/")
 }
 
 // NewDirectoryFilter creates a new directory filter
 //
 // Parameters:
 //   - excludeDirs: List of directory names to exclude (e.g., [".git", "node_modules"])
+//     Entries containing a path separator (e.g., "/srv/app/tmp") exclude
+//     only that directory, not every directory with the same name
 //
 // Returns:
 //   - *DirectoryFilter: Configured directory filter
@@ -259,15 +262,26 @@ type DirectoryFilter struct {
 //	}
 func NewDirectoryFilter(excludeDirs []string) *DirectoryFilter {
 	filter := &DirectoryFilter{
-		excludeDirs: make(map[string]bool),
+		excludeDirs:  make(map[string]bool),
+		excludePaths: make(map[string]bool),
 	}
 
 	// Convert slice to map for fast lookup
 	for _, dir := range excludeDirs {
 		cleanDir := strings.TrimSpace(dir)
-		if cleanDir != "" {
-			filter.excludeDirs[cleanDir] = true
+		if cleanDir == "" {
+			continue
 		}
+
+		if strings.ContainsAny(cleanDir, `/\`) {
+			// Full path: match this exact directory only
+			if abs, err := filepath.Abs(cleanDir); err == nil {
+				filter.excludePaths[abs] = true
+			}
+			continue
+		}
+
+		filter.excludeDirs[cleanDir] = true
 	}
 
 	return filter
@@ -294,12 +308,23 @@ func (df *DirectoryFilter) ShouldSkip(dirPath string) bool {
 	dirName := filepath.Base(dirPath)
 
 	// Check if this directory name is in the exclusion list
-	return df.excludeDirs[dirName]
+	if df.excludeDirs[dirName] {
+		return true
+	}
+
+	// Check full-path exclusions (e.g., "/srv/app/tmp")
+	if len(df.excludePaths) > 0 {
+		if abs, err := filepath.Abs(dirPath); err == nil {
+			return df.excludePaths[abs]
+		}
+	}
+
+	return false
 }
 
 // GetExcludeCount returns the number of excluded directories
 func (df *DirectoryFilter) GetExcludeCount() int {
-	return len(df.excludeDirs)
+	return len(df.excludeDirs) + len(df.excludePaths)
 }
 
 // IsExcluded checks if a directory name is excluded
@@ -0,0 +1,259 @@
+// Package filter - .gitignore-style ignore files
+// File: internal/filter/ignore_filter.go
+//
+// IgnoreFilter honours ignore files (".gitignore", ".panscanignore", ...)
+// found in the scanned directories, the same way git does. It replaces
+// long exclude_dirs lists for source trees: whatever the project already
+// ignores (build output, vendored code, caches) is skipped.
+//
+// RULES (gitignore syntax):
+//
+//	# comment           blank lines and lines starting with "#" are ignored
+//	*.log               no "/": matches a name at any depth below the file
+//	/build              leading "/": relative to the ignore file's directory
+//	docs/*.pdf          "/" in the middle: also relative to that directory
+//	cache/              trailing "/": matches directories only
+//	**/fixtures         "**" matches any number of directories
+//	!keep.log           "!" re-includes a previously ignored path
+//	\#file, \!file      backslash escapes a leading "#" or "!"
+//
+// HIERARCHY:
+//   - Each directory from the scan root down may have its own ignore files
+//   - Rules in deeper directories override rules in their parents, and
+//     later rules override earlier ones (the last matching rule wins)
+//   - With several ignore file names in one directory, later names win
+//     (".panscanignore" after ".gitignore" can re-include with "!")
+//   - As in git, a file can't be re-included if its directory is ignored
+//     (the directory is never entered)
+package filter
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// IgnoreFilter matches paths against ignore files found during a walk
+// Rules are loaded once per directory and cached
+//
+// Not safe for concurrent use: call it from the (single) walk goroutine
+type IgnoreFilter struct {
+	fileNames []string                // Ignore file names, lowest priority first
+	cache     map[string][]ignoreRule // Directory => rules from its ignore files
+	errs      []*os.PathError         // Unreadable ignore files not yet taken
+}
+
+// ignoreRule is one compiled line of an ignore file
+type ignoreRule struct {
+	negate  bool           // "!" prefix: re-include
+	dirOnly bool           // Trailing "/": directories only
+	regex   *regexp.Regexp // Matches "/" + path relative to the rule's directory
+}
+
+// NewIgnoreFilter creates an ignore filter
+//
+// Parameters:
+//   - fileNames: Ignore file names to look for in every directory,
+//     lowest priority first (e.g., ".gitignore", ".panscanignore")
+//
+// Returns:
+//   - *IgnoreFilter: Filter ready to use (nil if fileNames is empty)
+//
+// Example:
+//
+//	ignore := NewIgnoreFilter([]string{".gitignore", ".panscanignore"})
+//	if ignore.ShouldIgnore("/src/app", "/src/app/dist", true) {
+//	    return filepath.SkipDir
+//	}
+func NewIgnoreFilter(fileNames []string) *IgnoreFilter {
+	var names []string
+	for _, name := range fileNames {
+		name = strings.TrimSpace(name)
+		if name != "" {
+			names = append(names, name)
+		}
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	return &IgnoreFilter{
+		fileNames: names,
+		cache:     make(map[string][]ignoreRule),
+	}
+}
+
+// ShouldIgnore determines if a path is ignored by the ignore files in
+// root and the directories between root and the path
+//
+// Parameters:
+//   - root: Directory the walk started from (ignore files above it are not read)
+//   - path: File or directory below root
+//   - isDir: true if path is a directory
+//
+// Returns:
+//   - bool: true if the path should be skipped
+//
+// Example:
+//
+//	// /src/app/.gitignore contains "*.log" and "!keep.log"
+//	ignore.ShouldIgnore("/src/app", "/src/app/logs/debug.log", false) // true
+//	ignore.ShouldIgnore("/src/app", "/src/app/logs/keep.log", false)  // false
+func (f *IgnoreFilter) ShouldIgnore(root, path string, isDir bool) bool {
+	if f == nil || path == root {
+		return false
+	}
+
+	root = filepath.Clean(root)
+	path = filepath.Clean(path)
+
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return false
+	}
+
+	// Directories from root down to the path's parent, shallowest first
+	dirs := []string{root}
+	parts := strings.Split(rel, string(filepath.Separator))
+	for i := 0; i < len(parts)-1; i++ {
+		dirs = append(dirs, filepath.Join(dirs[len(dirs)-1], parts[i]))
+	}
+
+	// Deepest directory first, last rule first: the first match decides
+	for i := len(dirs) - 1; i >= 0; i-- {
+		rules := f.rulesFor(dirs[i])
+		if len(rules) == 0 {
+			continue
+		}
+
+		relToDir := "/" + strings.Join(parts[i:], "/")
+		for j := len(rules) - 1; j >= 0; j-- {
+			rule := rules[j]
+			if rule.dirOnly && !isDir {
+				continue
+			}
+			if rule.regex.MatchString(relToDir) {
+				return !rule.negate
+			}
+		}
+	}
+
+	return false
+}
+
+// TakeErrors returns the ignore files that exist but could not be read
+// since the last call (their rules are not applied)
+//
+// The filter doesn't print anything: the scanner records these as
+// walk errors of the scan.
+//
+// Example:
+//
+//	skip := ignore.ShouldIgnore(root, path, false)
+//	for _, err := range ignore.TakeErrors() {
+//	    log.Printf("ignore file %s not applied: %v", err.Path, err.Err)
+//	}
+func (f *IgnoreFilter) TakeErrors() []*os.PathError {
+	if f == nil {
+		return nil
+	}
+	errs := f.errs
+	f.errs = nil
+	return errs
+}
+
+// rulesFor returns the rules from a directory's ignore files (cached)
+func (f *IgnoreFilter) rulesFor(dir string) []ignoreRule {
+	if rules, ok := f.cache[dir]; ok {
+		return rules
+	}
+
+	var rules []ignoreRule
+	for _, name := range f.fileNames {
+		filePath := filepath.Join(dir, name)
+		fileRules, err := parseIgnoreFile(filePath)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				pathErr, ok := err.(*os.PathError)
+				if !ok {
+					pathErr = &os.PathError{Op: "read", Path: filePath, Err: err}
+				}
+				f.errs = append(f.errs, pathErr)
+			}
+			continue
+		}
+		rules = append(rules, fileRules...)
+	}
+
+	f.cache[dir] = rules
+	return rules
+}
+
+// parseIgnoreFile reads and compiles one ignore file
+// Lines that can't be compiled are skipped, like git does
+func parseIgnoreFile(filePath string) ([]ignoreRule, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var rules []ignoreRule
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if rule, ok := parseIgnoreLine(scanner.Text()); ok {
+			rules = append(rules, rule)
+		}
+	}
+	return rules, scanner.Err()
+}
+
+// parseIgnoreLine compiles one gitignore line
+//
+// Returns:
+//   - ignoreRule: Compiled rule
+//   - bool: false for blank lines, comments and invalid patterns
+func parseIgnoreLine(line string) (ignoreRule, bool) {
+	line = strings.TrimSuffix(line, "\r")
+
+	// Trailing spaces are ignored unless escaped
+	if !strings.HasSuffix(line, `\ `) {
+		line = strings.TrimRight(line, " \t")
+	}
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignoreRule{}, false
+	}
+
+	var rule ignoreRule
+	if strings.HasPrefix(line, "!") {
+		rule.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\#`) || strings.HasPrefix(line, `\!`) {
+		line = line[1:]
+	}
+	line = strings.ReplaceAll(line, `\ `, " ")
+
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return ignoreRule{}, false
+	}
+
+	// A "/" anywhere but the end anchors the pattern to the ignore
+	// file's directory; otherwise it matches a name at any depth
+	if strings.Contains(line, "/") && !strings.HasPrefix(line, "/") {
+		line = "/" + line
+	}
+
+	re, err := regexp.Compile(globToRegex(line))
+	if err != nil {
+		return ignoreRule{}, false
+	}
+	rule.regex = re
+	return rule, true
+}
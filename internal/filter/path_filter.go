@@ -0,0 +1,282 @@
+// Package filter - Path rules (glob and regex include/exclude)
+// File: internal/filter/path_filter.go
+//
+// PathFilter decides by full path, where ExtensionFilter only looks at
+// the extension and DirectoryFilter at directory names.
+//
+// RULE SYNTAX (one rule per string):
+//
+//	"- <pattern>"    exclude matching paths
+//	"+ <pattern>"    include matching paths
+//
+// A pattern is a gitignore-style glob, or a regex when prefixed "re:"
+//
+//	/srv/app/tmp/           leading "/"   anchored to the filesystem root
+//	**/fixtures/*.json      no leading "/" matches at any depth
+//	/var/log/**/payment*.log
+//	cache/                  trailing "/"  matches directories only
+//	re:\.(bak|old)$         regex against the full slash-separated path
+//
+// Glob wildcards: "*" and "?" never cross "/", "**" matches any number
+// of directories, "[abc]" / "[!abc]" match one character from a set.
+//
+// EVALUATION (last match wins):
+//   - A rule matches a file if it matches the file's path or the path
+//     of any parent directory (excluding /srv/tmp excludes its content)
+//   - The LAST matching rule decides
+//   - No matching rule: scanned, unless the list has include rules,
+//     in which case only explicitly included files are scanned
+package filter
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// PathFilter applies ordered include/exclude rules to full paths
+type PathFilter struct {
+	rules      []pathRule
+	hasInclude bool // Any include rule => unmatched files are excluded
+}
+
+// pathRule is one compiled include/exclude rule
+type pathRule struct {
+	source  string         // Rule as written (for error messages)
+	include bool           // true = include, false = exclude
+	dirOnly bool           // Pattern ended with "/"
+	regex   *regexp.Regexp // Compiled glob or regex
+}
+
+// NewPathFilter compiles a list of include/exclude rules
+//
+// Parameters:
+//   - rules: Rules in order, each "+ pattern" or "- pattern"
+//
+// Returns:
+//   - *PathFilter: Filter ready to use (nil if rules is empty)
+//   - error: Error if a rule has no +/- prefix or an invalid pattern
+//
+// Example:
+//
+//	pf, err := NewPathFilter([]string{
+//	    "- **/fixtures/*.json",
+//	    "- re:^/srv/app/tmp(/|$)",
+//	    "+ /srv/app/tmp/keep/",
+//	})
+func NewPathFilter(rules []string) (*PathFilter, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	pf := &PathFilter{}
+
+	for _, rule := range rules {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		var include bool
+		switch rule[0] {
+		case '+':
+			include = true
+		case '-':
+			include = false
+		default:
+			return nil, fmt.Errorf("invalid path rule %q: must start with '+' (include) or '-' (exclude)", rule)
+		}
+
+		pattern := strings.TrimSpace(rule[1:])
+		if pattern == "" {
+			return nil, fmt.Errorf("invalid path rule %q: empty pattern", rule)
+		}
+
+		compiled := pathRule{source: rule, include: include}
+
+		if strings.HasPrefix(pattern, "re:") {
+			re, err := regexp.Compile(strings.TrimPrefix(pattern, "re:"))
+			if err != nil {
+				return nil, fmt.Errorf("invalid path rule %q: %w", rule, err)
+			}
+			compiled.regex = re
+		} else {
+			if strings.HasSuffix(pattern, "/") && pattern != "/" {
+				compiled.dirOnly = true
+				pattern = strings.TrimSuffix(pattern, "/")
+			}
+			re, err := regexp.Compile(globToRegex(pattern))
+			if err != nil {
+				return nil, fmt.Errorf("invalid path rule %q: %w", rule, err)
+			}
+			compiled.regex = re
+		}
+
+		pf.rules = append(pf.rules, compiled)
+		if include {
+			pf.hasInclude = true
+		}
+	}
+
+	if len(pf.rules) == 0 {
+		return nil, nil
+	}
+
+	return pf, nil
+}
+
+// ShouldScan determines if a file should be scanned
+//
+// Parameters:
+//   - filePath: Path of the file (relative paths are made absolute)
+//
+// Returns:
+//   - bool: true if the file should be scanned
+//
+// Example:
+//
+//	pf, _ := NewPathFilter([]string{"+ /var/log/**/payment*.log"})
+//	pf.ShouldScan("/var/log/app/payment-2025.log") // true
+//	pf.ShouldScan("/var/log/app/access.log")       // false
+func (pf *PathFilter) ShouldScan(filePath string) bool {
+	if pf == nil {
+		return true
+	}
+
+	index := pf.lastMatch(normalizePath(filePath), false)
+	if index < 0 {
+		return !pf.hasInclude
+	}
+	return pf.rules[index].include
+}
+
+// ShouldSkipDir determines if a whole directory can be skipped
+//
+// A directory is only pruned when it is excluded AND no later include
+// rule could re-include something below it. Otherwise it is walked and
+// each file is decided by ShouldScan.
+//
+// Parameters:
+//   - dirPath: Path of the directory
+//
+// Returns:
+//   - bool: true if nothing below the directory can be scanned
+func (pf *PathFilter) ShouldSkipDir(dirPath string) bool {
+	if pf == nil {
+		return false
+	}
+
+	index := pf.lastMatch(normalizePath(dirPath), true)
+	if index < 0 || pf.rules[index].include {
+		return false
+	}
+
+	for _, rule := range pf.rules[index+1:] {
+		if rule.include {
+			return false
+		}
+	}
+	return true
+}
+
+// GetRuleCount returns the number of rules
+func (pf *PathFilter) GetRuleCount() int {
+	if pf == nil {
+		return 0
+	}
+	return len(pf.rules)
+}
+
+// lastMatch returns the index of the last rule matching path or one of
+// its parent directories (-1 if none)
+func (pf *PathFilter) lastMatch(path string, isDir bool) int {
+	for i := len(pf.rules) - 1; i >= 0; i-- {
+		rule := pf.rules[i]
+
+		if (!rule.dirOnly || isDir) && rule.regex.MatchString(path) {
+			return i
+		}
+
+		// Parent directories: /a/b/c.txt checks /a/b and /a
+		for parent := parentDir(path); parent != ""; parent = parentDir(parent) {
+			if rule.regex.MatchString(parent) {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// parentDir returns the parent of a slash-separated absolute path
+// ("" once the root is reached)
+func parentDir(path string) string {
+	index := strings.LastIndex(path, "/")
+	if index <= 0 {
+		return ""
+	}
+	return path[:index]
+}
+
+// normalizePath returns an absolute, slash-separated path
+func normalizePath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	return filepath.ToSlash(path)
+}
+
+// globToRegex converts a gitignore-style glob to an anchored regex
+//
+// Examples:
+//
+//	"/srv/*.log"        => ^/srv/[^/]*\.log$
+//	"**/fixtures/*.json" => ^(?:.*/)?fixtures/[^/]*\.json$
+//	"tmp"               => ^(?:.*/)?tmp$
+func globToRegex(glob string) string {
+	var b strings.Builder
+	b.WriteString("^")
+
+	if strings.HasPrefix(glob, "/") {
+		glob = glob[1:]
+		b.WriteString("/")
+	} else {
+		// Unanchored: may start at any directory boundary
+		b.WriteString("(?:.*/)?")
+	}
+
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+		switch c {
+		case '*':
+			if strings.HasPrefix(glob[i:], "**/") {
+				b.WriteString("(?:.*/)?")
+				i += 2
+			} else if strings.HasPrefix(glob[i:], "**") {
+				b.WriteString(".*")
+				i++
+			} else {
+				b.WriteString("[^/]*")
+			}
+		case '?':
+			b.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(glob[i+1:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := glob[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	b.WriteString("$")
+	return b.String()
+}
@@ -54,9 +54,19 @@ func (e *CSVExporter) Export(report *Report, filename string) error {
 	writer.Write([]string{"SCAN INFORMATION"})
 	writer.Write([]string{"Scan Date", report.ScanDate.Format("2006-01-02 15:04:05")})
 	writer.Write([]string{"Directory", report.Directory})
+	for _, root := range multipleRoots(report) {
+		writer.Write([]string{"Path", root})
+	}
 	writer.Write([]string{"Duration", report.GetFormattedDuration()}) // Use formatted duration
 	writer.Write([]string{"Total Files", fmt.Sprintf("%d", report.TotalFiles)})
 	writer.Write([]string{"Scanned Files", fmt.Sprintf("%d", report.ScannedFiles)})
+	for _, skip := range report.SkipCounts() {
+		writer.Write([]string{"Skipped (" + skip.Reason + ")", fmt.Sprintf("%d", skip.Count)})
+	}
+	if report.BINDatabase.Checksum != "" {
+		writer.Write([]string{"BIN Database", report.BINDatabase.Version, report.BINDatabase.Source})
+		writer.Write([]string{"BIN Database SHA-256", report.BINDatabase.Checksum})
+	}
 	writer.Write([]string{""})
 
 	writer.Write([]string{"SUMMARY"})
@@ -153,12 +163,12 @@ func (e *CSVExporter) Export(report *Report, filename string) error {
 		writer.Write([]string{fmt.Sprintf("FILE: %s", filePath)})
 		writer.Write([]string{"Cards Found", fmt.Sprintf("%d", len(findings))})
 		writer.Write([]string{""})
-		writer.Write([]string{"Line Number", "Card Type", "Masked Card", "Timestamp"})
+		writer.Write([]string{"Location", "Card Type", "Masked Card", "Timestamp"})
 
 		// Findings for this file
 		for _, f := range findings {
 			writer.Write([]string{
-				fmt.Sprintf("%d", f.LineNumber),
+				f.Location(),
 				f.CardType,
 				f.MaskedCard,
 				f.Timestamp.Format("2006-01-02 15:04:05"),
@@ -167,5 +177,20 @@ func (e *CSVExporter) Export(report *Report, filename string) error {
 		writer.Write([]string{""})
 	}
 
+	// ============================================================
+	// SECTION 5: Unscanned Files
+	// ============================================================
+
+	writer.Write([]string{"UNSCANNED FILES"})
+	writer.Write([]string{"Path", "Stage", "Class", "Error"})
+	for _, fileErr := range report.Errors {
+		writer.Write([]string{
+			fileErr.Path,
+			fileErr.Stage,
+			string(fileErr.Class),
+			fileErr.Err.Error(),
+		})
+	}
+
 	return nil
 }
@@ -25,8 +25,13 @@ type Exporter interface {
 }
 
 // Available exporters (implemented in separate files):
-// - JSONExporter  - json_exporter.go
-// - CSVExporter   - csv_exporter.go
-// - TXTExporter   - txt_exporter.go
-// - XMLExporter   - xml_exporter.go
-// - HTMLExporter  - html_exporter.go
+// - JSONExporter     - json_exporter.go
+// - JSONLExporter    - jsonl_exporter.go
+// - CSVExporter      - csv_exporter.go
+// - TXTExporter      - txt_exporter.go
+// - XMLExporter      - xml_exporter.go
+// - HTMLExporter     - html_exporter.go
+// - PDFExporter      - pdf_exporter.go
+// - SARIFExporter    - sarif_exporter.go
+// - MarkdownExporter - markdown_exporter.go
+// - JUnitExporter    - junit_exporter.go
@@ -0,0 +1,161 @@
+// Package report - Output formats
+// Maps file extensions to exporters and writes several formats at once
+package report
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Format is one supported output format
+type Format struct {
+	Name      string // Name used with -format (e.g., "html")
+	Extension string // File extension, with dot (e.g., ".html")
+	exporter  func() Exporter
+}
+
+// Formats lists the supported output formats in display order
+var Formats = []Format{
+	{"json", ".json", func() Exporter { return &JSONExporter{} }},
+	{"jsonl", ".jsonl", func() Exporter { return &JSONLExporter{} }},
+	{"csv", ".csv", func() Exporter { return &CSVExporter{} }},
+	{"txt", ".txt", func() Exporter { return &TXTExporter{} }},
+	{"xml", ".xml", func() Exporter { return &XMLExporter{} }},
+	{"html", ".html", func() Exporter { return &HTMLExporter{} }},
+	{"pdf", ".pdf", func() Exporter { return &PDFExporter{} }},
+	{"sarif", ".sarif", func() Exporter { return &SARIFExporter{} }},
+	{"md", ".md", func() Exporter { return &MarkdownExporter{} }},
+	{"junit", ".junit.xml", func() Exporter { return &JUnitExporter{} }},
+}
+
+// FormatNames returns the names of all formats ("json, csv, ...")
+// Used in help and error messages
+func FormatNames() string {
+	names := make([]string, len(Formats))
+	for i, format := range Formats {
+		names[i] = format.Name
+	}
+	return strings.Join(names, ", ")
+}
+
+// FormatByName returns the format with the given name (case-insensitive)
+//
+// Returns:
+//   - Format: The format
+//   - error: Error if no format has this name
+func FormatByName(name string) (Format, error) {
+	for _, format := range Formats {
+		if strings.EqualFold(format.Name, strings.TrimSpace(name)) {
+			return format, nil
+		}
+	}
+	return Format{}, fmt.Errorf("unknown format '%s' (use %s)", name, FormatNames())
+}
+
+// FormatForFile returns the format chosen by a file's extension
+// The longest matching extension wins: scan.junit.xml is JUnit, not XML.
+//
+// Returns:
+//   - Format: The format
+//   - error: Error if the extension is not supported
+func FormatForFile(filename string) (Format, error) {
+	name := strings.ToLower(filename)
+	var match *Format
+	for i, format := range Formats {
+		if strings.HasSuffix(name, format.Extension) && (match == nil || len(format.Extension) > len(match.Extension)) {
+			match = &Formats[i]
+		}
+	}
+	if match != nil {
+		return *match, nil
+	}
+
+	ext := strings.ToLower(filepath.Ext(filename))
+
+	extensions := make([]string, len(Formats))
+	for i, format := range Formats {
+		extensions[i] = format.Extension
+	}
+	return Format{}, fmt.Errorf("unsupported format: %s (use %s)", ext, strings.Join(extensions, ", "))
+}
+
+// CheckOutputs verifies a list of output files before a scan starts
+// Every file needs a supported extension and may appear only once
+// (two exporters writing the same file at the same time would clash)
+//
+// Parameters:
+//   - filenames: Output files
+//
+// Returns:
+//   - error: First problem found
+func CheckOutputs(filenames []string) error {
+	seen := make(map[string]bool)
+	for _, filename := range filenames {
+		if _, err := FormatForFile(filename); err != nil {
+			return fmt.Errorf("%s: %w", filename, err)
+		}
+
+		key := filepath.Clean(filename)
+		if seen[key] {
+			return fmt.Errorf("%s is given more than once", filename)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// ExportResult is the outcome of writing one output file
+type ExportResult struct {
+	Filename string        // File written (or attempted)
+	Format   string        // Format name (e.g., "html"), "" if unsupported
+	Duration time.Duration // Time the export took
+	Err      error         // nil on success
+}
+
+// ExportAll writes the report to several files concurrently
+// Each file's format is chosen from its extension. A failing format
+// doesn't stop the others; check each result's Err.
+//
+// Parameters:
+//   - filenames: Output files
+//
+// Returns:
+//   - []ExportResult: One result per file, in the order given
+//
+// Example:
+//
+//	for _, res := range rep.ExportAll([]string{"scan.json", "scan.html", "scan.sarif"}) {
+//	    if res.Err != nil {
+//	        log.Printf("%s: %v", res.Filename, res.Err)
+//	    }
+//	}
+func (r *Report) ExportAll(filenames []string) []ExportResult {
+	results := make([]ExportResult, len(filenames))
+
+	// Exporters only read the report, so they can share it
+	var wg sync.WaitGroup
+	for i, filename := range filenames {
+		results[i].Filename = filename
+
+		format, err := FormatForFile(filename)
+		if err != nil {
+			results[i].Err = err
+			continue
+		}
+		results[i].Format = format.Name
+
+		wg.Add(1)
+		go func(res *ExportResult, exporter Exporter) {
+			defer wg.Done()
+			start := time.Now()
+			res.Err = exporter.Export(r, res.Filename)
+			res.Duration = time.Since(start)
+		}(&results[i], format.exporter())
+	}
+	wg.Wait()
+
+	return results
+}
@@ -4,6 +4,7 @@ package report
 
 import (
 	"fmt"
+	"html/template"
 	"os"
 	"path/filepath"
 	"sort"
@@ -526,6 +527,27 @@ func (e *HTMLExporter) Export(report *Report, filename string) error {
             font-size: 14px;
         }
         
+        .unscanned-item {
+            background: white;
+            padding: 14px 20px;
+            margin: 10px 0;
+            border-radius: 8px;
+            border-left: 4px solid #f39c12;
+        }
+
+        .unscanned-item .unscanned-path {
+            font-family: 'Courier New', 'Consolas', monospace;
+            font-weight: 600;
+            font-size: 13px;
+            word-break: break-all;
+        }
+
+        .unscanned-item .unscanned-error {
+            color: #7f8c8d;
+            font-size: 13px;
+            margin-top: 6px;
+        }
+
         .finding-card {
             font-family: 'Courier New', 'Consolas', monospace;
             color: #e74c3c;
@@ -823,14 +845,14 @@ func (e *HTMLExporter) Export(report *Report, filename string) error {
 
 				html.WriteString(fmt.Sprintf(`
                             <div class="finding-item">
-                                <div class="finding-line">Line %d</div>
+                                <div class="finding-line">%s</div>
                                 <div class="finding-type">
                                     %s
                                     <span>%s</span>
                                 </div>
                                 <div class="finding-card">%s</div>
                             </div>`,
-					finding.LineNumber,
+					finding.Location(),
 					cardIcon,
 					finding.CardType,
 					finding.MaskedCard))
@@ -854,6 +876,34 @@ func (e *HTMLExporter) Export(report *Report, filename string) error {
             </div>`)
 	}
 
+	// ============================================================
+	// UNSCANNED FILES (proof of coverage)
+	// ============================================================
+
+	html.WriteString(`
+            <div class="stats-section">
+                <h2>⚠️ Unscanned Files</h2>`)
+
+	if len(report.Errors) > 0 {
+		for _, fileErr := range report.Errors {
+			html.WriteString(fmt.Sprintf(`
+                <div class="unscanned-item">
+                    <div class="unscanned-path">%s</div>
+                    <div class="unscanned-error">%s during %s: %s</div>
+                </div>`,
+				template.HTMLEscapeString(fileErr.Path),
+				fileErr.Class,
+				fileErr.Stage,
+				template.HTMLEscapeString(fileErr.Err.Error())))
+		}
+	} else {
+		html.WriteString(`
+                <p>✅ None - every selected file was scanned</p>`)
+	}
+
+	html.WriteString(`
+            </div>`)
+
 	// ============================================================
 	// FOOTER
 	// ============================================================
@@ -863,7 +913,7 @@ func (e *HTMLExporter) Export(report *Report, filename string) error {
         
         <footer>
             <p><strong>BasicPanScanner v` + report.Version + `</strong></p>
-            <p>PCI DSS Compliance Tool | Secure Card Detection</p>
+            <p>PCI DSS Compliance Tool | Secure Card Detection</p>` + htmlBINDatabase(report) + `
             <p class="timestamp">Report generated on ` + report.ScanDate.Format("Monday, January 2, 2006 at 15:04:05 MST") + `</p>
         </footer>
     </div>
@@ -965,3 +1015,16 @@ func toJSONIntArray(items []int) string {
 	}
 	return "[" + strings.Join(parts, ",") + "]"
 }
+
+// htmlBINDatabase returns the footer line naming the BIN database
+// ("" if the report doesn't record one)
+func htmlBINDatabase(report *Report) string {
+	if report.BINDatabase.Checksum == "" {
+		return ""
+	}
+	return fmt.Sprintf(`
+            <p class="timestamp">BIN database v%s (%s) | SHA-256 %s</p>`,
+		template.HTMLEscapeString(report.BINDatabase.Version),
+		template.HTMLEscapeString(report.BINDatabase.Source),
+		report.BINDatabase.Checksum)
+}
@@ -5,8 +5,118 @@ package report
 import (
 	"encoding/json"
 	"os"
+	"time"
+
+	"github.com/keraattin/BasicPanScanner/internal/scanner"
 )
 
+// ============================================================
+// JSON DOCUMENT STRUCTURE
+// ============================================================
+
+// JSONSchemaVersion is the version of the JSON report document
+// Increase it when a field changes meaning or is removed; adding
+// optional fields doesn't need a new version. Reports written before
+// the field existed have no schema_version and are read as version 1.
+const JSONSchemaVersion = 2
+
+// jsonTimeFormat keeps sub-second precision so times survive a round trip
+const jsonTimeFormat = time.RFC3339Nano
+
+// jsonFinding is a single finding
+// Text files have a line number, databases have table/column/row
+type jsonFinding struct {
+	LineNumber int    `json:"line_number,omitempty"`
+	Schema     string `json:"schema,omitempty"`
+	Table      string `json:"table,omitempty"`
+	Column     string `json:"column,omitempty"`
+	RowID      int64  `json:"row_id,omitempty"`
+	PrimaryKey string `json:"primary_key,omitempty"`
+	Commit     string `json:"commit,omitempty"`
+	Author     string `json:"author,omitempty"`
+	CommitDate string `json:"commit_date,omitempty"`
+	VersionID  string `json:"version_id,omitempty"`
+	ETag       string `json:"etag,omitempty"`
+	CardType   string `json:"card_type"`
+	MaskedCard string `json:"masked_card"`
+	Timestamp  string `json:"timestamp"`
+}
+
+// newJSONFinding converts a finding (the full card number is left out)
+func newJSONFinding(f scanner.Finding) jsonFinding {
+	return jsonFinding{
+		LineNumber: f.LineNumber,
+		Schema:     f.Schema,
+		Table:      f.Table,
+		Column:     f.Column,
+		RowID:      f.RowID,
+		PrimaryKey: f.PrimaryKey,
+		Commit:     f.CommitSHA,
+		Author:     f.CommitAuthor,
+		CommitDate: commitDate(f),
+		VersionID:  f.ObjectVersion,
+		ETag:       f.ETag,
+		CardType:   f.CardType,
+		MaskedCard: f.MaskedCard,
+		Timestamp:  f.Timestamp.Format(jsonTimeFormat),
+	}
+}
+
+// jsonBINDatabase identifies the BIN database the scan used
+type jsonBINDatabase struct {
+	Version  string `json:"version"`
+	Source   string `json:"source"`
+	Checksum string `json:"sha256"`
+}
+
+// jsonFileError is a file that could not be scanned
+type jsonFileError struct {
+	Path  string `json:"path"`
+	Stage string `json:"stage"`
+	Class string `json:"class"`
+	Error string `json:"error"`
+}
+
+// jsonReport is the document written by JSONExporter and read by LoadJSON
+// This provides a well-organized format that's easy to parse
+//
+// Everything a Report holds except full card numbers is written, so
+// LoadJSON can rebuild the report for the other exporters. "duration"
+// is for people; "duration_ns" is the exact value.
+type jsonReport struct {
+	SchemaVersion int    `json:"schema_version"`
+	Version       string `json:"version"`
+	ScanInfo      struct {
+		ScanDate     string           `json:"scan_date"`
+		Directory    string           `json:"directory"`
+		Roots        []string         `json:"roots,omitempty"`
+		ScanMode     string           `json:"scan_mode,omitempty"`
+		Extensions   []string         `json:"extensions,omitempty"`
+		Duration     string           `json:"duration"`
+		DurationNS   int64            `json:"duration_ns"`
+		ScanRate     float64          `json:"scan_rate"`
+		TotalFiles   int              `json:"total_files"`
+		ScannedFiles int              `json:"scanned_files"`
+		Skipped      map[string]int   `json:"skipped,omitempty"`
+		Unscanned    map[string]int   `json:"unscanned,omitempty"`
+		BINDatabase  *jsonBINDatabase `json:"bin_database,omitempty"`
+	} `json:"scan_info"`
+	Summary struct {
+		TotalCards      int `json:"total_cards"`
+		FilesWithCards  int `json:"files_with_cards"`
+		HighRiskFiles   int `json:"high_risk_files"`
+		MediumRiskFiles int `json:"medium_risk_files"`
+		LowRiskFiles    int `json:"low_risk_files"`
+	} `json:"summary"`
+	Statistics struct {
+		CardsByType map[string]int `json:"cards_by_type"`
+		FilesByType map[string]int `json:"files_by_type"`
+		TopFiles    []FileStats    `json:"top_files"`
+	} `json:"statistics"`
+	Findings       map[string][]jsonFinding `json:"findings"`
+	UnscannedFiles []jsonFileError          `json:"unscanned_files"`
+}
+
 // JSONExporter exports reports in JSON format
 // JSON is ideal for:
 //   - Machine-readable output
@@ -28,11 +138,14 @@ type JSONExporter struct{}
 // Example output structure:
 //
 //	{
+//	  "schema_version": 2,
 //	  "version": "3.0.0",
 //	  "scan_info": {
 //	    "scan_date": "2025-01-15T10:30:00Z",
 //	    "directory": "/var/log",
-//	    "duration": "1m23s"
+//	    "scan_mode": "blacklist",
+//	    "duration": "1m 23s",
+//	    "duration_ns": 83000000000
 //	  },
 //	  "summary": {
 //	    "total_cards": 12,
@@ -42,45 +155,39 @@ type JSONExporter struct{}
 //	  "findings": {...}
 //	}
 func (e *JSONExporter) Export(report *Report, filename string) error {
-	// Create a clean JSON structure
-	// This provides a well-organized format that's easy to parse
-	type jsonReport struct {
-		Version  string `json:"version"`
-		ScanInfo struct {
-			ScanDate     string `json:"scan_date"`
-			Directory    string `json:"directory"`
-			Duration     string `json:"duration"`
-			TotalFiles   int    `json:"total_files"`
-			ScannedFiles int    `json:"scanned_files"`
-		} `json:"scan_info"`
-		Summary struct {
-			TotalCards      int `json:"total_cards"`
-			FilesWithCards  int `json:"files_with_cards"`
-			HighRiskFiles   int `json:"high_risk_files"`
-			MediumRiskFiles int `json:"medium_risk_files"`
-			LowRiskFiles    int `json:"low_risk_files"`
-		} `json:"summary"`
-		Statistics struct {
-			CardsByType map[string]int `json:"cards_by_type"`
-			FilesByType map[string]int `json:"files_by_type"`
-			TopFiles    []FileStats    `json:"top_files"`
-		} `json:"statistics"`
-		Findings map[string][]struct {
-			LineNumber int    `json:"line_number"`
-			CardType   string `json:"card_type"`
-			MaskedCard string `json:"masked_card"`
-			Timestamp  string `json:"timestamp"`
-		} `json:"findings"`
-	}
-
 	// Build the JSON structure
-	jr := jsonReport{}
+	jr := jsonReport{SchemaVersion: JSONSchemaVersion}
 	jr.Version = report.Version
-	jr.ScanInfo.ScanDate = report.ScanDate.Format("2006-01-02T15:04:05Z07:00")
+	jr.ScanInfo.ScanDate = report.ScanDate.Format(jsonTimeFormat)
 	jr.ScanInfo.Directory = report.Directory
+	jr.ScanInfo.Roots = multipleRoots(report)
+	jr.ScanInfo.ScanMode = report.ScanMode
+	jr.ScanInfo.Extensions = report.Extensions
 	jr.ScanInfo.Duration = report.GetFormattedDuration() // Use formatted duration
+	jr.ScanInfo.DurationNS = int64(report.Duration)
+	jr.ScanInfo.ScanRate = report.ScanRate
 	jr.ScanInfo.TotalFiles = report.TotalFiles
 	jr.ScanInfo.ScannedFiles = report.ScannedFiles
+	if report.BINDatabase.Checksum != "" {
+		jr.ScanInfo.BINDatabase = &jsonBINDatabase{
+			Version:  report.BINDatabase.Version,
+			Source:   report.BINDatabase.Source,
+			Checksum: report.BINDatabase.Checksum,
+		}
+	}
+	for _, skip := range report.SkipCounts() {
+		if jr.ScanInfo.Skipped == nil {
+			jr.ScanInfo.Skipped = make(map[string]int)
+		}
+		jr.ScanInfo.Skipped[skip.Reason] = skip.Count
+	}
+
+	for _, count := range report.ErrorCounts() {
+		if jr.ScanInfo.Unscanned == nil {
+			jr.ScanInfo.Unscanned = make(map[string]int)
+		}
+		jr.ScanInfo.Unscanned[count.Reason] = count.Count
+	}
 
 	jr.Summary.TotalCards = report.CardsFound
 	jr.Summary.FilesWithCards = report.Statistics.FilesWithCards
@@ -93,38 +200,29 @@ func (e *JSONExporter) Export(report *Report, filename string) error {
 	jr.Statistics.TopFiles = report.Statistics.TopFiles
 
 	// Convert findings
-	jr.Findings = make(map[string][]struct {
-		LineNumber int    `json:"line_number"`
-		CardType   string `json:"card_type"`
-		MaskedCard string `json:"masked_card"`
-		Timestamp  string `json:"timestamp"`
-	})
+	jr.Findings = make(map[string][]jsonFinding)
 
 	for filePath, findings := range report.GroupedByFile {
-		var fileFindings []struct {
-			LineNumber int    `json:"line_number"`
-			CardType   string `json:"card_type"`
-			MaskedCard string `json:"masked_card"`
-			Timestamp  string `json:"timestamp"`
-		}
+		var fileFindings []jsonFinding
 
 		for _, f := range findings {
-			fileFindings = append(fileFindings, struct {
-				LineNumber int    `json:"line_number"`
-				CardType   string `json:"card_type"`
-				MaskedCard string `json:"masked_card"`
-				Timestamp  string `json:"timestamp"`
-			}{
-				LineNumber: f.LineNumber,
-				CardType:   f.CardType,
-				MaskedCard: f.MaskedCard,
-				Timestamp:  f.Timestamp.Format("2006-01-02T15:04:05Z07:00"),
-			})
+			fileFindings = append(fileFindings, newJSONFinding(f))
 		}
 
 		jr.Findings[filePath] = fileFindings
 	}
 
+	// Unscanned files (always present, [] = full coverage)
+	jr.UnscannedFiles = make([]jsonFileError, 0, len(report.Errors))
+	for _, fileErr := range report.Errors {
+		jr.UnscannedFiles = append(jr.UnscannedFiles, jsonFileError{
+			Path:  fileErr.Path,
+			Stage: fileErr.Stage,
+			Class: string(fileErr.Class),
+			Error: fileErr.Err.Error(),
+		})
+	}
+
 	// Marshal to JSON with indentation for readability
 	data, err := json.MarshalIndent(jr, "", "  ")
 	if err != nil {
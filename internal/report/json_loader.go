@@ -0,0 +1,188 @@
+// Package report - JSON loader
+// Rebuilds a Report from a file written by JSONExporter, so a finished
+// scan can be exported again in another format without rescanning
+package report
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/keraattin/BasicPanScanner/internal/scanner"
+)
+
+// LoadJSON reads a JSON report written by JSONExporter
+//
+// Everything in the document is restored: scan information, scan mode
+// and extensions, timing, skip counts, findings, unscanned files and the
+// BIN database. Statistics are recalculated from the findings. Full card
+// numbers are never written to JSON, so findings only carry the masked
+// card.
+//
+// Reports without a schema_version (written before the schema was
+// versioned) are read as version 1: the duration is parsed from its
+// display form and the scan mode and extensions are unknown. Reports
+// from a newer schema are refused rather than half-read.
+//
+// Parameters:
+//   - filename: JSON report to read
+//
+// Returns:
+//   - *Report: Report ready for any exporter
+//   - error: Error if the file can't be read or isn't a JSON report
+//
+// Example:
+//
+//	rep, err := report.LoadJSON("scan.json")
+//	if err == nil {
+//	    err = rep.Export("scan.html")
+//	}
+func LoadJSON(filename string) (*Report, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read report: %w", err)
+	}
+
+	var jr jsonReport
+	if err := json.Unmarshal(data, &jr); err != nil {
+		return nil, fmt.Errorf("failed to parse report JSON: %w", err)
+	}
+	if jr.ScanInfo.ScanDate == "" {
+		return nil, fmt.Errorf("%s is not a scanner JSON report (no scan_info)", filename)
+	}
+	if jr.SchemaVersion > JSONSchemaVersion {
+		return nil, fmt.Errorf("%s uses report schema %d, this scanner reads up to %d (upgrade the scanner)",
+			filename, jr.SchemaVersion, JSONSchemaVersion)
+	}
+
+	// ============================================================
+	// Scan information
+	// ============================================================
+	rep := &Report{
+		Version:      jr.Version,
+		Directory:    jr.ScanInfo.Directory,
+		Roots:        jr.ScanInfo.Roots,
+		ScanMode:     jr.ScanInfo.ScanMode,
+		Extensions:   jr.ScanInfo.Extensions,
+		TotalFiles:   jr.ScanInfo.TotalFiles,
+		ScannedFiles: jr.ScanInfo.ScannedFiles,
+		CardsFound:   jr.Summary.TotalCards,
+	}
+	if len(rep.Roots) == 0 && rep.Directory != "" {
+		rep.Roots = []string{rep.Directory}
+	}
+
+	if rep.ScanDate, err = time.Parse(jsonTimeFormat, jr.ScanInfo.ScanDate); err != nil {
+		return nil, fmt.Errorf("invalid scan_date: %w", err)
+	}
+
+	if jr.SchemaVersion >= 2 {
+		rep.Duration = time.Duration(jr.ScanInfo.DurationNS)
+		rep.ScanRate = jr.ScanInfo.ScanRate
+	} else if d, err := time.ParseDuration(strings.ReplaceAll(jr.ScanInfo.Duration, " ", "")); err == nil {
+		// Version 1 only has the FormatDuration text ("1h 23m 45s", "3.6s")
+		rep.Duration = d
+		if d > 0 {
+			rep.ScanRate = float64(rep.ScannedFiles) / d.Seconds()
+		}
+	}
+
+	if db := jr.ScanInfo.BINDatabase; db != nil {
+		rep.BINDatabase = BINDatabaseInfo{Version: db.Version, Source: db.Source, Checksum: db.Checksum}
+	}
+
+	for reason, count := range jr.ScanInfo.Skipped {
+		if field := rep.skipField(reason); field != nil {
+			*field = count
+		}
+	}
+
+	// ============================================================
+	// Findings (sorted by path: JSON objects have no order)
+	// ============================================================
+	paths := make([]string, 0, len(jr.Findings))
+	for path := range jr.Findings {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	rep.GroupedByFile = make(map[string][]scanner.Finding)
+	for _, path := range paths {
+		for _, jf := range jr.Findings[path] {
+			finding := scanner.Finding{
+				FilePath:      path,
+				LineNumber:    jf.LineNumber,
+				CardType:      jf.CardType,
+				MaskedCard:    jf.MaskedCard,
+				Schema:        jf.Schema,
+				Table:         jf.Table,
+				Column:        jf.Column,
+				RowID:         jf.RowID,
+				PrimaryKey:    jf.PrimaryKey,
+				CommitSHA:     jf.Commit,
+				CommitAuthor:  jf.Author,
+				ObjectVersion: jf.VersionID,
+				ETag:          jf.ETag,
+			}
+			finding.Timestamp, _ = time.Parse(jsonTimeFormat, jf.Timestamp)
+			if jf.CommitDate != "" {
+				finding.CommitDate, _ = time.Parse(jsonTimeFormat, jf.CommitDate)
+			}
+
+			rep.Findings = append(rep.Findings, finding)
+			rep.GroupedByFile[path] = append(rep.GroupedByFile[path], finding)
+		}
+	}
+	if rep.CardsFound == 0 {
+		rep.CardsFound = len(rep.Findings)
+	}
+
+	// ============================================================
+	// Unscanned files
+	// ============================================================
+	for _, fe := range jr.UnscannedFiles {
+		rep.Errors = append(rep.Errors, scanner.FileError{
+			Path:  fe.Path,
+			Stage: fe.Stage,
+			Class: scanner.ErrorClass(fe.Class),
+			Err:   errors.New(fe.Error),
+		})
+	}
+
+	rep.calculateStatistics()
+	return rep, nil
+}
+
+// skipField returns the counter for a skip reason (nil if unknown)
+// Reasons are the ones SkipCounts writes
+func (r *Report) skipField(reason string) *int {
+	switch reason {
+	case scanner.SkipReasonSize:
+		return &r.SkippedBySize
+	case scanner.SkipReasonExt:
+		return &r.SkippedByExt
+	case scanner.SkipReasonPath:
+		return &r.SkippedByPath
+	case scanner.SkipReasonContent:
+		return &r.SkippedByContent
+	case scanner.SkipReasonAge:
+		return &r.SkippedByAge
+	case scanner.SkipReasonOwner:
+		return &r.SkippedByOwner
+	case scanner.SkipReasonMinSize:
+		return &r.SkippedByMinSize
+	case scanner.SkipReasonIgnore:
+		return &r.SkippedByIgnore
+	case scanner.SkipReasonSymlink:
+		return &r.SkippedSymlinks
+	case scanner.SkipReasonSpecial:
+		return &r.SkippedSpecial
+	case scanner.SkipReasonMount:
+		return &r.SkippedMounts
+	}
+	return nil
+}
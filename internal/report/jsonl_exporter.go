@@ -0,0 +1,255 @@
+// Package report - JSON Lines exporter
+// Writes one finding per line while the scan runs, then a summary line
+package report
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/keraattin/BasicPanScanner/internal/scanner"
+)
+
+// JSONLSchemaVersion is the version of the JSON Lines records
+// Increase it when a field changes meaning or is removed
+const JSONLSchemaVersion = 1
+
+// Record types ("type" field of every line)
+const (
+	JSONLFinding = "finding" // One card
+	JSONLSummary = "summary" // Last line: totals of the scan
+)
+
+// ============================================================
+// JSON LINES RECORDS
+// ============================================================
+
+// jsonlFinding is a finding line: the JSON report's finding plus its path
+type jsonlFinding struct {
+	Type string `json:"type"`
+	Path string `json:"path"`
+	jsonFinding
+}
+
+// jsonlSummary is the last line, written when the scan is over
+// Its absence means the scan did not finish
+type jsonlSummary struct {
+	Type           string           `json:"type"`
+	SchemaVersion  int              `json:"schema_version"`
+	Version        string           `json:"version"`
+	ScanDate       string           `json:"scan_date"`
+	Directory      string           `json:"directory"`
+	Roots          []string         `json:"roots,omitempty"`
+	ScanMode       string           `json:"scan_mode,omitempty"`
+	Duration       string           `json:"duration"`
+	DurationNS     int64            `json:"duration_ns"`
+	TotalFiles     int              `json:"total_files"`
+	ScannedFiles   int              `json:"scanned_files"`
+	CardsFound     int              `json:"cards_found"`
+	FilesWithCards int              `json:"files_with_cards"`
+	Skipped        map[string]int   `json:"skipped,omitempty"`
+	Unscanned      map[string]int   `json:"unscanned,omitempty"`
+	BINDatabase    *jsonBINDatabase `json:"bin_database,omitempty"`
+	UnscannedFiles []jsonFileError  `json:"unscanned_files"`
+}
+
+// ============================================================
+// STREAMING WRITER
+// ============================================================
+
+// JSONLWriter writes findings to a .jsonl file as they are found
+// It implements scanner.FindingSink: set it as the scanner's Sink and
+// each file's findings are on disk as soon as the file is scanned.
+// Close writes the summary line once the Report exists.
+//
+// Example:
+//
+//	w, err := report.NewJSONLWriter("findings.jsonl")
+//	if err != nil {
+//	    return err
+//	}
+//	config.Sink = w
+//	config.SinkOnly = true // Nothing else needs the findings
+//	result, _ := scanner.NewScanner(config).ScanDirectory("/srv")
+//	err = w.Close(report.NewReport("3.0.0", "/srv", "blacklist", nil, result))
+type JSONLWriter struct {
+	file  *os.File
+	out   *bufio.Writer
+	enc   *json.Encoder
+	paths map[string]bool // Files with findings (for the summary)
+	cards int             // Findings written
+	err   error           // First write error (reported by Close)
+}
+
+// NewJSONLWriter creates (or truncates) a JSON Lines file
+//
+// Parameters:
+//   - filename: Output filename (should end with .jsonl)
+//
+// Returns:
+//   - *JSONLWriter: Writer ready for findings
+//   - error: Error if the file can't be created
+func NewJSONLWriter(filename string) (*JSONLWriter, error) {
+	file, err := os.Create(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	out := bufio.NewWriter(file)
+	return &JSONLWriter{
+		file:  file,
+		out:   out,
+		enc:   json.NewEncoder(out), // Encode adds the newline
+		paths: make(map[string]bool),
+	}, nil
+}
+
+// WriteFindings writes one line per finding and flushes them to disk
+// (scanner.FindingSink interface)
+func (w *JSONLWriter) WriteFindings(path string, findings []scanner.Finding) {
+	if w.err != nil {
+		return
+	}
+
+	for _, f := range findings {
+		record := jsonlFinding{Type: JSONLFinding, Path: path, jsonFinding: newJSONFinding(f)}
+		if w.err = w.enc.Encode(record); w.err != nil {
+			return
+		}
+	}
+	w.paths[path] = true
+	w.cards += len(findings)
+
+	// Readers (tail -f, a pipeline) see the file's findings right away
+	w.err = w.out.Flush()
+}
+
+// Name returns the file being written
+func (w *JSONLWriter) Name() string {
+	return w.file.Name()
+}
+
+// Findings returns the number of findings written so far
+func (w *JSONLWriter) Findings() int {
+	return w.cards
+}
+
+// Close writes the summary line and closes the file
+//
+// Parameters:
+//   - report: The finished scan (nil = scan failed, no summary)
+//
+// Returns:
+//   - error: First error of any write, or of closing the file
+func (w *JSONLWriter) Close(report *Report) error {
+	if w.err == nil && report != nil {
+		w.err = w.enc.Encode(newJSONLSummary(report, len(w.paths)))
+	}
+	if w.err == nil {
+		w.err = w.out.Flush()
+	}
+	if err := w.file.Close(); w.err == nil {
+		w.err = err
+	}
+	return w.err
+}
+
+// newJSONLSummary builds the summary line of a report
+func newJSONLSummary(report *Report, filesWithCards int) jsonlSummary {
+	summary := jsonlSummary{
+		Type:           JSONLSummary,
+		SchemaVersion:  JSONLSchemaVersion,
+		Version:        report.Version,
+		ScanDate:       report.ScanDate.Format(jsonTimeFormat),
+		Directory:      report.Directory,
+		Roots:          multipleRoots(report),
+		ScanMode:       report.ScanMode,
+		Duration:       report.GetFormattedDuration(),
+		DurationNS:     int64(report.Duration),
+		TotalFiles:     report.TotalFiles,
+		ScannedFiles:   report.ScannedFiles,
+		CardsFound:     report.CardsFound,
+		FilesWithCards: filesWithCards,
+		UnscannedFiles: make([]jsonFileError, 0, len(report.Errors)),
+	}
+
+	if report.BINDatabase.Checksum != "" {
+		summary.BINDatabase = &jsonBINDatabase{
+			Version:  report.BINDatabase.Version,
+			Source:   report.BINDatabase.Source,
+			Checksum: report.BINDatabase.Checksum,
+		}
+	}
+	for _, skip := range report.SkipCounts() {
+		if summary.Skipped == nil {
+			summary.Skipped = make(map[string]int)
+		}
+		summary.Skipped[skip.Reason] = skip.Count
+	}
+	for _, count := range report.ErrorCounts() {
+		if summary.Unscanned == nil {
+			summary.Unscanned = make(map[string]int)
+		}
+		summary.Unscanned[count.Reason] = count.Count
+	}
+	for _, fileErr := range report.Errors {
+		summary.UnscannedFiles = append(summary.UnscannedFiles, jsonFileError{
+			Path:  fileErr.Path,
+			Stage: fileErr.Stage,
+			Class: string(fileErr.Class),
+			Error: fileErr.Err.Error(),
+		})
+	}
+	return summary
+}
+
+// ============================================================
+// EXPORTER (finished reports)
+// ============================================================
+
+// JSONLExporter exports a finished report in JSON Lines format
+// JSON Lines is ideal for:
+//   - Log pipelines (one event per line)
+//   - Very large results (read line by line, never as one document)
+//   - jq, grep and other line-oriented tools
+//
+// A scan writes .jsonl outputs with JSONLWriter while it runs; this
+// exporter writes the same lines from a Report (e.g. "report convert").
+type JSONLExporter struct{}
+
+// Export implements the Exporter interface for JSON Lines format
+//
+// Parameters:
+//   - report: The report to export
+//   - filename: Output filename (should end with .jsonl)
+//
+// Returns:
+//   - error: Error if file can't be written
+//
+// Example output:
+//
+//	{"type":"finding","path":"/var/log/app.log","line_number":12,"card_type":"Visa",...}
+//	{"type":"summary","schema_version":1,"version":"3.0.0","cards_found":1,...}
+func (e *JSONLExporter) Export(report *Report, filename string) error {
+	w, err := NewJSONLWriter(filename)
+	if err != nil {
+		return err
+	}
+
+	filePaths := make([]string, 0, len(report.GroupedByFile))
+	for filePath := range report.GroupedByFile {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+
+	for _, filePath := range filePaths {
+		w.WriteFindings(filePath, report.GroupedByFile[filePath])
+	}
+
+	if err := w.Close(report); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filename, err)
+	}
+	return nil
+}
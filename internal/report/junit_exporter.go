@@ -0,0 +1,221 @@
+// Package report - JUnit XML exporter
+// Exports reports as JUnit test results for CI pipelines
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/keraattin/BasicPanScanner/internal/scanner"
+)
+
+// ============================================================
+// JUNIT DOCUMENT STRUCTURE
+// ============================================================
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Time       string          `xml:"time,attr"`
+	Timestamp  string          `xml:"timestamp,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	TestCases  []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitProblem `xml:"failure,omitempty"`
+	Error     *junitProblem `xml:"error,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",cdata"`
+}
+
+// JUnitExporter exports reports as JUnit XML test results
+// JUnit XML is ideal for:
+//   - CI test result views (GitLab, Jenkins, Azure DevOps, GitHub actions)
+//   - Failing a pipeline stage on findings
+//   - Tracking which files are fixed between runs
+//
+// Every scanned file is a test case: files with cards fail, files that
+// could not be scanned are errors and clean files pass. Reports loaded
+// from JSON don't list the clean files; they are one passing test case.
+type JUnitExporter struct{}
+
+// Export implements the Exporter interface for JUnit XML format
+//
+// Parameters:
+//   - report: The report to export
+//   - filename: Output filename (should end with .junit.xml)
+//
+// Returns:
+//   - error: Error if file can't be written or XML encoding fails
+//
+// Example output:
+//
+//	<testsuites name="BasicPanScanner" tests="3" failures="1" errors="1" time="0.412">
+//	  <testsuite name="/srv" tests="3" failures="1" errors="1" skipped="0" ...>
+//	    <testcase name="/srv/app.log" classname="BasicPanScanner">
+//	      <failure message="2 card numbers found (Medium risk)" type="PAN001">Line 12: Visa 411111******1111 ...</failure>
+//	    </testcase>
+//	    <testcase name="/srv/broken.zip" classname="BasicPanScanner">
+//	      <error message="Not scanned (parse, extract stage)" type="parse">...</error>
+//	    </testcase>
+//	    <testcase name="/srv/readme.txt" classname="BasicPanScanner"></testcase>
+//	  </testsuite>
+//	</testsuites>
+func (e *JUnitExporter) Export(report *Report, filename string) error {
+	suite := junitTestSuite{
+		Name:       report.Directory,
+		Time:       fmt.Sprintf("%.3f", report.Duration.Seconds()),
+		Timestamp:  report.ScanDate.Format("2006-01-02T15:04:05"),
+		Properties: junitProperties(report),
+		TestCases:  []junitTestCase{},
+	}
+
+	// ============================================================
+	// One test case per path (a git path scanned in several commits
+	// is one test case with the findings of all of them)
+	// ============================================================
+	notScanned := make(map[string][]scanner.FileError)
+	for _, fileErr := range report.Errors {
+		notScanned[fileErr.Path] = append(notScanned[fileErr.Path], fileErr)
+	}
+
+	paths := make(map[string]bool)
+	for _, path := range report.ScannedPaths {
+		paths[path] = true
+	}
+	for path := range report.GroupedByFile {
+		paths[path] = true
+	}
+	for path := range notScanned {
+		paths[path] = true
+	}
+
+	sorted := make([]string, 0, len(paths))
+	for path := range paths {
+		sorted = append(sorted, path)
+	}
+	sort.Strings(sorted)
+
+	for _, path := range sorted {
+		testCase := junitTestCase{Name: path, ClassName: "BasicPanScanner"}
+		findings := report.GroupedByFile[path]
+		fileErrs := notScanned[path]
+
+		problems := make([]string, len(fileErrs))
+		for i, fileErr := range fileErrs {
+			problems[i] = fmt.Sprintf("Not scanned (%s, %s stage): %v", fileErr.Class, fileErr.Stage, fileErr.Err)
+		}
+
+		switch {
+		case len(findings) > 0:
+			// Cards found (maybe in a partly read file: noted, still a failure)
+			lines := make([]string, 0, len(findings)+len(problems))
+			for _, f := range findings {
+				lines = append(lines, fmt.Sprintf("%s: %s %s", f.Location(), f.CardType, f.MaskedCard))
+			}
+			lines = append(lines, problems...)
+			testCase.Failure = &junitProblem{
+				Message: fmt.Sprintf("%s found (%s risk)", cardCount(len(findings)), FileRiskLevel(len(findings))),
+				Type:    sarifRuleID,
+				Text:    strings.Join(lines, "\n"),
+			}
+			suite.Failures++
+		case len(fileErrs) > 0:
+			testCase.Error = &junitProblem{
+				Message: problems[0],
+				Type:    string(fileErrs[0].Class),
+				Text:    strings.Join(problems, "\n"),
+			}
+			suite.Errors++
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	// Reports without the list of scanned files: the clean ones in one test case
+	if len(report.ScannedPaths) == 0 {
+		if clean := report.ScannedFiles - len(report.GroupedByFile); clean > 0 {
+			suite.TestCases = append(suite.TestCases, junitTestCase{
+				Name:      fmt.Sprintf("Other scanned files (%d)", clean),
+				ClassName: "BasicPanScanner",
+				SystemOut: "No card numbers found. The report does not list these files by name.",
+			})
+		}
+	}
+	suite.Tests = len(suite.TestCases)
+
+	doc := junitTestSuites{
+		Name:     "BasicPanScanner",
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Errors:   suite.Errors,
+		Time:     suite.Time,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	data, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append([]byte(xml.Header), data...)
+	return os.WriteFile(filename, append(data, '\n'), 0644)
+}
+
+// junitProperties lists the scan settings as suite properties
+func junitProperties(report *Report) []junitProperty {
+	properties := []junitProperty{
+		{Name: "scanner.version", Value: report.Version},
+		{Name: "scan.mode", Value: report.ScanMode},
+		{Name: "scan.totalFiles", Value: fmt.Sprint(report.TotalFiles)},
+		{Name: "scan.scannedFiles", Value: fmt.Sprint(report.ScannedFiles)},
+		{Name: "scan.cardsFound", Value: fmt.Sprint(report.CardsFound)},
+	}
+	for _, root := range multipleRoots(report) {
+		properties = append(properties, junitProperty{Name: "scan.root", Value: root})
+	}
+	for _, skip := range report.SkipCounts() {
+		properties = append(properties, junitProperty{Name: "scan.skipped." + strings.ReplaceAll(skip.Reason, " ", "_"), Value: fmt.Sprint(skip.Count)})
+	}
+	if report.BINDatabase.Checksum != "" {
+		properties = append(properties,
+			junitProperty{Name: "binDatabase.version", Value: report.BINDatabase.Version},
+			junitProperty{Name: "binDatabase.checksum", Value: report.BINDatabase.Checksum})
+	}
+	return properties
+}
+
+// cardCount formats a number of cards: "1 card number", "3 card numbers"
+func cardCount(n int) string {
+	if n == 1 {
+		return "1 card number"
+	}
+	return fmt.Sprintf("%d card numbers", n)
+}
@@ -0,0 +1,291 @@
+// Package report - Markdown exporter
+// Exports reports as GitHub/GitLab-flavored Markdown for CI job summaries
+// and pull request comments
+package report
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/keraattin/BasicPanScanner/internal/scanner"
+)
+
+// Limits that keep the Markdown small enough for a PR comment
+// (GitHub allows 65536 characters)
+const (
+	markdownMaxFindings  = 500 // Finding rows
+	markdownMaxUnscanned = 100 // Unscanned file rows
+)
+
+// MarkdownExporter exports reports in Markdown format
+// Markdown is ideal for:
+//   - CI job summaries ($GITHUB_STEP_SUMMARY)
+//   - Pull / merge request comments
+//   - Wiki pages and tickets
+//
+// Findings show masked card numbers only. Line-based findings link to
+// their line: to the repository on GitHub Actions and GitLab CI, and
+// relative to the report file elsewhere.
+type MarkdownExporter struct {
+	// LinkBase is put in front of workspace-relative paths to link
+	// findings, e.g. "https://github.com/org/repo/blob/main/"
+	// "" = from GitHub Actions / GitLab CI variables if set
+	LinkBase string
+}
+
+// Export implements the Exporter interface for Markdown format
+//
+// Parameters:
+//   - report: The report to export
+//   - filename: Output filename (should end with .md)
+//
+// Returns:
+//   - error: Error if file can't be written
+//
+// Example output:
+//
+//	# BasicPanScanner Report
+//
+//	**🟡 2 card numbers found in 1 file (risk: Medium)**
+//
+//	| | |
+//	|---|---|
+//	| **Target** | `/srv` |
+//	...
+//
+//	### 🟡 `app.log` (2 card numbers)
+//
+//	| Location | Card type | Card number |
+//	|---|---|---|
+//	| [Line 12](app.log#L12) | Visa | `411111******1111` |
+func (e *MarkdownExporter) Export(report *Report, filename string) error {
+	var content strings.Builder
+	link := e.linker(filename)
+	stats := report.Statistics
+
+	// ============================================================
+	// HEADER AND SUMMARY
+	// ============================================================
+
+	content.WriteString("# BasicPanScanner Report\n\n")
+
+	level, _ := report.GetRiskLevel()
+	if report.CardsFound == 0 {
+		content.WriteString("**✅ No card numbers found**\n\n")
+	} else {
+		content.WriteString(fmt.Sprintf("**%s %s found in %s (risk: %s)**\n\n",
+			riskEmoji(level), cardCount(report.CardsFound), fileCount(stats.FilesWithCards), level))
+	}
+
+	target := mdCode(report.Directory)
+	if roots := multipleRoots(report); roots != nil {
+		codes := make([]string, len(roots))
+		for i, root := range roots {
+			codes[i] = mdCode(root)
+		}
+		target = strings.Join(codes, "<br>")
+	}
+
+	content.WriteString("| | |\n|---|---|\n")
+	content.WriteString(fmt.Sprintf("| **Target** | %s |\n", target))
+	content.WriteString(fmt.Sprintf("| **Scan date** | %s |\n", report.ScanDate.Format("2006-01-02 15:04:05")))
+	content.WriteString(fmt.Sprintf("| **Duration** | %s |\n", report.GetFormattedDuration()))
+	content.WriteString(fmt.Sprintf("| **Files scanned** | %d of %d |\n", report.ScannedFiles, report.TotalFiles))
+	if len(report.Errors) > 0 {
+		content.WriteString(fmt.Sprintf("| **Not scanned** | %d (see below) |\n", len(report.Errors)))
+	}
+	content.WriteString(fmt.Sprintf("| **Cards found** | %d |\n", report.CardsFound))
+	if report.CardsFound > 0 {
+		content.WriteString(fmt.Sprintf("| **Files with cards** | %d (🔴 %d high, 🟡 %d medium, 🟢 %d low) |\n",
+			stats.FilesWithCards, stats.HighRiskFiles, stats.MediumRiskFiles, stats.LowRiskFiles))
+	}
+	if report.BINDatabase.Checksum != "" {
+		content.WriteString(fmt.Sprintf("| **BIN database** | v%s (`%.12s`) |\n",
+			mdCell(report.BINDatabase.Version), report.BINDatabase.Checksum))
+	}
+	content.WriteString("\n")
+
+	// ============================================================
+	// CARD TYPES
+	// ============================================================
+
+	if len(stats.CardsByType) > 0 {
+		content.WriteString("## Cards by Type\n\n")
+		content.WriteString("| Card type | Cards |\n|---|--:|\n")
+
+		cardTypes := make([]string, 0, len(stats.CardsByType))
+		for cardType := range stats.CardsByType {
+			cardTypes = append(cardTypes, cardType)
+		}
+		sort.Slice(cardTypes, func(i, j int) bool {
+			if stats.CardsByType[cardTypes[i]] != stats.CardsByType[cardTypes[j]] {
+				return stats.CardsByType[cardTypes[i]] > stats.CardsByType[cardTypes[j]]
+			}
+			return cardTypes[i] < cardTypes[j]
+		})
+		for _, cardType := range cardTypes {
+			content.WriteString(fmt.Sprintf("| %s | %d |\n", mdCell(cardType), stats.CardsByType[cardType]))
+		}
+		content.WriteString("\n")
+	}
+
+	// ============================================================
+	// FINDINGS (files with the most cards first)
+	// ============================================================
+
+	if len(report.GroupedByFile) > 0 {
+		content.WriteString("## Findings\n\n")
+
+		filePaths := make([]string, 0, len(report.GroupedByFile))
+		for filePath := range report.GroupedByFile {
+			filePaths = append(filePaths, filePath)
+		}
+		sort.Slice(filePaths, func(i, j int) bool {
+			ci, cj := len(report.GroupedByFile[filePaths[i]]), len(report.GroupedByFile[filePaths[j]])
+			if ci != cj {
+				return ci > cj
+			}
+			return filePaths[i] < filePaths[j]
+		})
+
+		rows := 0
+		for i, filePath := range filePaths {
+			findings := report.GroupedByFile[filePath]
+			if rows+len(findings) > markdownMaxFindings && rows > 0 {
+				remaining := 0
+				for _, rest := range filePaths[i:] {
+					remaining += len(report.GroupedByFile[rest])
+				}
+				content.WriteString(fmt.Sprintf("_...and %s in %s more. See the JSON or HTML report for all findings._\n\n",
+					cardCount(remaining), fileCount(len(filePaths)-i)))
+				break
+			}
+
+			content.WriteString(fmt.Sprintf("### %s %s (%s)\n\n",
+				riskEmoji(FileRiskLevel(len(findings))), mdCode(filePath), cardCount(len(findings))))
+			content.WriteString("| Location | Card type | Card number |\n|---|---|---|\n")
+			for _, f := range findings {
+				location := mdCell(f.Location())
+				if target := link(filePath, f); target != "" {
+					location = fmt.Sprintf("[%s](%s)", location, target)
+				}
+				content.WriteString(fmt.Sprintf("| %s | %s | %s |\n", location, mdCell(f.CardType), mdCode(f.MaskedCard)))
+			}
+			content.WriteString("\n")
+			rows += len(findings)
+		}
+	}
+
+	// ============================================================
+	// UNSCANNED FILES
+	// ============================================================
+
+	if len(report.Errors) > 0 {
+		content.WriteString("## Not Scanned\n\n")
+		content.WriteString("| File | Cause | Stage | Error |\n|---|---|---|---|\n")
+		for i, fileErr := range report.Errors {
+			if i == markdownMaxUnscanned {
+				content.WriteString(fmt.Sprintf("\n_...and %d more._\n", len(report.Errors)-i))
+				break
+			}
+			content.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n",
+				mdCode(fileErr.Path), fileErr.Class, fileErr.Stage, mdCell(fileErr.Err.Error())))
+		}
+		content.WriteString("\n")
+	}
+
+	content.WriteString("---\n")
+	content.WriteString(fmt.Sprintf("_Generated by BasicPanScanner v%s. Card numbers are masked._\n", report.Version))
+
+	return os.WriteFile(filename, []byte(content.String()), 0644)
+}
+
+// linker returns the function that links a finding to its line
+// The function returns "" for findings without a linkable line:
+// database rows, S3 objects and git history (the path may not exist
+// in the current tree).
+//
+// Parameters:
+//   - filename: The Markdown file (relative links start from its directory)
+func (e *MarkdownExporter) linker(filename string) func(path string, f scanner.Finding) string {
+	base, root := e.LinkBase, ""
+	switch {
+	case base != "":
+		root, _ = os.Getwd()
+	case os.Getenv("GITHUB_ACTIONS") == "true" && os.Getenv("GITHUB_REPOSITORY") != "" && os.Getenv("GITHUB_SHA") != "":
+		server := os.Getenv("GITHUB_SERVER_URL")
+		if server == "" {
+			server = "https://github.com"
+		}
+		base = server + "/" + os.Getenv("GITHUB_REPOSITORY") + "/blob/" + os.Getenv("GITHUB_SHA") + "/"
+		root = os.Getenv("GITHUB_WORKSPACE")
+	case os.Getenv("GITLAB_CI") == "true" && os.Getenv("CI_PROJECT_URL") != "" && os.Getenv("CI_COMMIT_SHA") != "":
+		base = os.Getenv("CI_PROJECT_URL") + "/-/blob/" + os.Getenv("CI_COMMIT_SHA") + "/"
+		root = os.Getenv("CI_PROJECT_DIR")
+	default:
+		// Outside CI: relative to the report, so links work where it is viewed
+		root, _ = filepath.Abs(filepath.Dir(filename))
+	}
+	if root == "" {
+		root, _ = os.Getwd()
+	}
+
+	return func(path string, f scanner.Finding) string {
+		if f.LineNumber <= 0 || f.Table != "" || f.CommitSHA != "" || strings.Contains(path, "://") {
+			return ""
+		}
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return ""
+		}
+		rel, err := filepath.Rel(root, abs)
+		if err != nil || (base != "" && strings.HasPrefix(rel, "..")) {
+			return "" // Outside the repository
+		}
+
+		segments := strings.Split(filepath.ToSlash(rel), "/")
+		for i, segment := range segments {
+			segments[i] = url.PathEscape(segment)
+		}
+		return fmt.Sprintf("%s%s#L%d", base, strings.Join(segments, "/"), f.LineNumber)
+	}
+}
+
+// riskEmoji returns the indicator of a risk level (as in the TXT report)
+func riskEmoji(level string) string {
+	switch level {
+	case RiskHigh:
+		return "🔴"
+	case RiskMedium:
+		return "🟡"
+	}
+	return "🟢"
+}
+
+// fileCount formats a number of files: "1 file", "3 files"
+func fileCount(n int) string {
+	if n == 1 {
+		return "1 file"
+	}
+	return fmt.Sprintf("%d files", n)
+}
+
+// mdCell makes text safe for a Markdown table cell
+// (pipes would end the cell, newlines the row, HTML would render)
+func mdCell(text string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ", "\r", " ", "<", "&lt;", ">", "&gt;").Replace(text)
+}
+
+// mdCode formats text as inline code that is safe in a table cell
+// Text containing a backtick gets a double-backtick span.
+func mdCode(text string) string {
+	text = strings.NewReplacer("|", `\|`, "\n", " ", "\r", " ").Replace(text)
+	if strings.Contains(text, "`") {
+		return "`` " + text + " ``"
+	}
+	return "`" + text + "`"
+}
@@ -318,6 +318,20 @@ func (e *PDFExporter) buildAllPages(report *Report) []string {
 		}
 	}
 
+	// ============================================================
+	// UNSCANNED FILES
+	// ============================================================
+	e.checkPageBreak(&currentPage, &pages, 100)
+	e.addSectionTitle(&currentPage, "UNSCANNED FILES")
+	if len(report.Errors) == 0 {
+		e.addTextLine(&currentPage, "None - every selected file was scanned", colorBlack)
+	}
+	for _, fileErr := range report.Errors {
+		e.checkPageBreak(&currentPage, &pages, 40)
+		e.addTextLine(&currentPage, e.truncate(fileErr.Path, 90), colorBlack)
+		e.addTextLine(&currentPage, e.truncate(fmt.Sprintf("  %s during %s: %v", fileErr.Class, fileErr.Stage, fileErr.Err), 100), colorGray)
+	}
+
 	// Add footer to last page
 	e.addFooter(&currentPage)
 
@@ -544,6 +558,18 @@ func (e *PDFExporter) addExecutiveSummary(page *strings.Builder, report *Report)
 	page.WriteString(fmt.Sprintf("(Directory: %s) Tj\n", e.escape(e.truncate(report.Directory, 55))))
 	page.WriteString("ET\n")
 
+	if report.BINDatabase.Checksum != "" {
+		page.WriteString("BT\n")
+		page.WriteString("0.3 0.3 0.3 rg\n")
+		page.WriteString("/F1 9 Tf\n")
+		page.WriteString(fmt.Sprintf("%.1f %.1f Td\n", e.marginLeft+160, riskY-27))
+		page.WriteString(fmt.Sprintf("(BIN database: v%s, %s, SHA-256 %.16s...) Tj\n",
+			e.escape(report.BINDatabase.Version),
+			e.escape(e.truncate(report.BINDatabase.Source, 30)),
+			report.BINDatabase.Checksum))
+		page.WriteString("ET\n")
+	}
+
 	// Move Y position down
 	e.currentY -= boxHeight + 20
 
@@ -733,6 +759,26 @@ func (e *PDFExporter) addFileFindings(page *strings.Builder, filePath string, fi
 	e.currentY -= 50
 }
 
+// ============================================================
+// TEXT LINE
+// ============================================================
+// addTextLine adds one line of small text (used for unscanned files)
+//
+// Parameters:
+//   - page: The string builder for page content
+//   - text: Text to write (escaped here)
+//   - color: Text color (one of the color constants)
+func (e *PDFExporter) addTextLine(page *strings.Builder, text string, color string) {
+	page.WriteString("BT\n")
+	page.WriteString(color + " rg\n")
+	page.WriteString("/F1 9 Tf\n")
+	page.WriteString(fmt.Sprintf("%.1f %.1f Td\n", e.marginLeft+10, e.currentY-12))
+	page.WriteString(fmt.Sprintf("(%s) Tj\n", e.escape(text)))
+	page.WriteString("ET\n")
+
+	e.currentY -= 15
+}
+
 // ============================================================
 // FOOTER
 // ============================================================
@@ -8,7 +8,7 @@ import (
 	"strings"
 	"time"
 
-	"../scanner"
+	"github.com/keraattin/BasicPanScanner/internal/scanner"
 )
 
 // Report represents a complete scan report
@@ -18,17 +18,28 @@ type Report struct {
 	Version   string    // Scanner version (e.g., "3.0.0")
 	ScanDate  time.Time // When the scan started
 	Directory string    // Directory that was scanned
+	Roots     []string  // All paths scanned (more than one with multiple -path / -files-from)
 
 	// Configuration
-	ScanMode   string   // "whitelist" or "blacklist"
-	Extensions []string // Extensions that were scanned/excluded
+	ScanMode    string          // "whitelist" or "blacklist"
+	Extensions  []string        // Extensions that were scanned/excluded
+	BINDatabase BINDatabaseInfo // BIN database the scan used (optional)
 
 	// Results
-	TotalFiles    int // Total files found
-	ScannedFiles  int // Files actually scanned
-	SkippedBySize int // Files skipped due to size
-	SkippedByExt  int // Files skipped by extension filter
-	CardsFound    int // Total cards found
+	TotalFiles       int // Total files found
+	ScannedFiles     int // Files actually scanned
+	SkippedBySize    int // Files skipped due to size
+	SkippedByExt     int // Files skipped by extension filter
+	SkippedByPath    int // Files skipped by path include/exclude rules
+	SkippedByContent int // Files skipped as binary/unsupported by content sniffing
+	SkippedByAge     int // Files skipped by newer-than/older-than
+	SkippedByOwner   int // Files skipped by owner/group filter
+	SkippedByMinSize int // Files skipped as smaller than the minimum size
+	SkippedByIgnore  int // Files skipped by .gitignore-style ignore files
+	SkippedSymlinks  int // Directory symlinks not followed (or broken / looping links)
+	SkippedSpecial   int // FIFOs, sockets and devices
+	SkippedMounts    int // Mount points not crossed (one-filesystem mode)
+	CardsFound       int // Total cards found
 
 	// Timing
 	Duration time.Duration // Total scan duration
@@ -38,10 +49,25 @@ type Report struct {
 	Findings      []scanner.Finding            // All findings (flat list)
 	GroupedByFile map[string][]scanner.Finding // Findings grouped by file
 
+	// Files that were scanned (a git path once per commit)
+	// Empty for reports loaded from JSON and for sink-only scans
+	ScannedPaths []string
+
+	// Files that could not be (fully) scanned, with stage and cause
+	Errors []scanner.FileError
+
 	// Statistics
 	Statistics Statistics // Computed statistics
 }
 
+// BINDatabaseInfo identifies the BIN database a scan used
+// Recorded so findings can be traced back to an exact dataset
+type BINDatabaseInfo struct {
+	Version  string // Database version (e.g., "3.0.0")
+	Source   string // "embedded" or the file it was loaded from
+	Checksum string // Hex SHA-256 of the database JSON
+}
+
 // Statistics holds computed statistics about the scan
 // These are calculated after scanning completes
 type Statistics struct {
@@ -75,7 +101,7 @@ type FileStats struct {
 //
 // Parameters:
 //   - version: Scanner version
-//   - directory: Directory that was scanned
+//   - directory: Directory that was scanned ("" = list of result.Roots)
 //   - scanMode: "whitelist" or "blacklist"
 //   - extensions: Extensions list
 //   - result: Scan results from scanner package
@@ -94,20 +120,36 @@ type FileStats struct {
 //	)
 func NewReport(version, directory, scanMode string, extensions []string, result *scanner.ScanResult) *Report {
 	rep := &Report{
-		Version:       version,
-		ScanDate:      time.Now(),
-		Directory:     directory,
-		ScanMode:      scanMode,
-		Extensions:    extensions,
-		TotalFiles:    result.TotalFiles,
-		ScannedFiles:  result.ScannedFiles,
-		SkippedBySize: result.SkippedBySize,
-		SkippedByExt:  result.SkippedByExt,
-		CardsFound:    result.CardsFound,
-		Duration:      result.Duration,
-		ScanRate:      result.ScanRate,
-		Findings:      result.Findings,
-		GroupedByFile: result.GroupedByFile,
+		Version:          version,
+		ScanDate:         time.Now(),
+		Directory:        directory,
+		ScanMode:         scanMode,
+		Extensions:       extensions,
+		TotalFiles:       result.TotalFiles,
+		ScannedFiles:     result.ScannedFiles,
+		ScannedPaths:     result.ScannedPaths,
+		SkippedBySize:    result.SkippedBySize,
+		SkippedByExt:     result.SkippedByExt,
+		SkippedByPath:    result.SkippedByPath,
+		SkippedByContent: result.SkippedByContent,
+		SkippedByAge:     result.SkippedByAge,
+		SkippedByOwner:   result.SkippedByOwner,
+		SkippedByMinSize: result.SkippedByMinSize,
+		SkippedByIgnore:  result.SkippedByIgnore,
+		SkippedSymlinks:  result.SkippedSymlinks,
+		SkippedSpecial:   result.SkippedSpecial,
+		SkippedMounts:    result.SkippedMounts,
+		CardsFound:       result.CardsFound,
+		Duration:         result.Duration,
+		ScanRate:         result.ScanRate,
+		Findings:         result.Findings,
+		GroupedByFile:    result.GroupedByFile,
+		Errors:           result.Errors,
+		Roots:            result.Roots,
+	}
+
+	if rep.Directory == "" {
+		rep.Directory = strings.Join(result.Roots, ", ")
 	}
 
 	// Calculate statistics
@@ -146,12 +188,12 @@ func (r *Report) calculateStatistics() {
 		}
 
 		// Determine risk level based on card count
-		cardCount := len(findings)
-		if cardCount >= 5 {
+		switch FileRiskLevel(len(findings)) {
+		case RiskHigh:
 			stats.HighRiskFiles++
-		} else if cardCount >= 2 {
+		case RiskMedium:
 			stats.MediumRiskFiles++
-		} else {
+		default:
 			stats.LowRiskFiles++
 		}
 	}
@@ -201,11 +243,14 @@ func (r *Report) calculateStatistics() {
 // Format is determined by file extension
 //
 // Supported formats:
-//   - .json - JSON format
-//   - .csv  - CSV format
-//   - .txt  - Plain text format
-//   - .xml  - XML format
-//   - .html - HTML format
+//   - .json  - JSON format
+//   - .jsonl - JSON Lines (one finding per line, then a summary)
+//   - .csv   - CSV format
+//   - .txt   - Plain text format
+//   - .xml   - XML format
+//   - .html  - HTML format
+//   - .pdf   - PDF format
+//   - .sarif - SARIF 2.1.0 (code scanning tools)
 //
 // Parameters:
 //   - filename: Output filename with extension
@@ -221,29 +266,13 @@ func (r *Report) calculateStatistics() {
 //	}
 func (r *Report) Export(filename string) error {
 	// Determine format from file extension
-	ext := strings.ToLower(filepath.Ext(filename))
-
-	var exporter Exporter
-
-	switch ext {
-	case ".json":
-		exporter = &JSONExporter{}
-	case ".csv":
-		exporter = &CSVExporter{}
-	case ".txt":
-		exporter = &TXTExporter{}
-	case ".xml":
-		exporter = &XMLExporter{}
-	case ".html":
-		exporter = &HTMLExporter{}
-	case ".pdf":
-		exporter = &PDFExporter{}
-	default:
-		return fmt.Errorf("unsupported format: %s (use .json, .csv, .txt, .xml, or .html)", ext)
+	format, err := FormatForFile(filename)
+	if err != nil {
+		return err
 	}
 
 	// Use the exporter to write the report
-	return exporter.Export(r, filename)
+	return format.exporter().Export(r, filename)
 }
 
 // GetRiskLevel returns the overall risk level of the scan
@@ -253,13 +282,49 @@ func (r *Report) Export(filename string) error {
 //   - string: "High", "Medium", or "Low"
 //   - string: Color code for display ("#e74c3c" for high, etc.)
 func (r *Report) GetRiskLevel() (level string, color string) {
+	level = RiskLow
 	if r.Statistics.HighRiskFiles > 0 {
-		return "High", "#e74c3c" // Red
+		level = RiskHigh
+	} else if r.Statistics.MediumRiskFiles > 0 {
+		level = RiskMedium
 	}
-	if r.Statistics.MediumRiskFiles > 0 {
-		return "Medium", "#f39c12" // Orange
+	return level, RiskColor(level)
+}
+
+// Risk levels of files and scans
+const (
+	RiskHigh   = "High"   // 5+ cards in a file
+	RiskMedium = "Medium" // 2-4 cards in a file
+	RiskLow    = "Low"    // 1 card in a file
+)
+
+// FileRiskLevel returns the risk level of a file with cardCount cards
+//
+// Returns:
+//   - string: RiskHigh (5+), RiskMedium (2-4) or RiskLow (0-1)
+func FileRiskLevel(cardCount int) string {
+	switch {
+	case cardCount >= 5:
+		return RiskHigh
+	case cardCount >= 2:
+		return RiskMedium
 	}
-	return "Low", "#27ae60" // Green
+	return RiskLow
+}
+
+// RiskColor returns the display color of a risk level
+//
+// Returns:
+//   - string: "#e74c3c" (red) for High, "#f39c12" (orange) for Medium,
+//     "#27ae60" (green) otherwise
+func RiskColor(level string) string {
+	switch level {
+	case RiskHigh:
+		return "#e74c3c" // Red
+	case RiskMedium:
+		return "#f39c12" // Orange
+	}
+	return "#27ae60" // Green
 }
 
 // GetFormattedDuration returns a human-readable duration string
@@ -358,3 +423,82 @@ func FormatDuration(d time.Duration) string {
 	}
 	return fmt.Sprintf("%dh %dm %ds", hours, minutes, seconds)
 }
+
+// commitDate formats the commit date of a git history finding
+// Returns "" for findings that don't come from git history
+func commitDate(f scanner.Finding) string {
+	if f.CommitDate.IsZero() {
+		return ""
+	}
+	return f.CommitDate.Format("2006-01-02T15:04:05Z07:00")
+}
+
+// SkipCount is the number of files skipped for one reason
+type SkipCount struct {
+	Reason string // e.g. "size", "extension", "age"
+	Count  int    // Files skipped for this reason
+}
+
+// SkipCounts returns the skip categories with at least one file
+// in a fixed order, for exporters that list why files were skipped
+//
+// Example:
+//
+//	for _, skip := range report.SkipCounts() {
+//	    fmt.Printf("Skipped (%s): %d\n", skip.Reason, skip.Count)
+//	}
+func (r *Report) SkipCounts() []SkipCount {
+	all := []SkipCount{
+		{scanner.SkipReasonSize, r.SkippedBySize},
+		{scanner.SkipReasonExt, r.SkippedByExt},
+		{scanner.SkipReasonPath, r.SkippedByPath},
+		{scanner.SkipReasonContent, r.SkippedByContent},
+		{scanner.SkipReasonAge, r.SkippedByAge},
+		{scanner.SkipReasonOwner, r.SkippedByOwner},
+		{scanner.SkipReasonMinSize, r.SkippedByMinSize},
+		{scanner.SkipReasonIgnore, r.SkippedByIgnore},
+		{scanner.SkipReasonSymlink, r.SkippedSymlinks},
+		{scanner.SkipReasonSpecial, r.SkippedSpecial},
+		{scanner.SkipReasonMount, r.SkippedMounts},
+	}
+
+	var counts []SkipCount
+	for _, skip := range all {
+		if skip.Count > 0 {
+			counts = append(counts, skip)
+		}
+	}
+	return counts
+}
+
+// ErrorCounts returns the number of unscanned files per error class,
+// for classes with at least one file, in scanner.ErrorClasses order
+//
+// Example:
+//
+//	for _, count := range report.ErrorCounts() {
+//	    fmt.Printf("Unscanned (%s): %d\n", count.Reason, count.Count)
+//	}
+func (r *Report) ErrorCounts() []SkipCount {
+	byClass := make(map[scanner.ErrorClass]int)
+	for _, fileErr := range r.Errors {
+		byClass[fileErr.Class]++
+	}
+
+	var counts []SkipCount
+	for _, class := range scanner.ErrorClasses {
+		if byClass[class] > 0 {
+			counts = append(counts, SkipCount{Reason: string(class), Count: byClass[class]})
+		}
+	}
+	return counts
+}
+
+// multipleRoots returns the scanned paths when there is more than one
+// Returns nil for single-path scans (Directory already says it all)
+func multipleRoots(r *Report) []string {
+	if len(r.Roots) > 1 {
+		return r.Roots
+	}
+	return nil
+}
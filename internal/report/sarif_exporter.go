@@ -0,0 +1,246 @@
+// Package report - SARIF exporter
+// Exports reports in SARIF 2.1.0 for code scanning and CI tools
+package report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/keraattin/BasicPanScanner/internal/scanner"
+)
+
+// sarifRuleID is the rule every finding is reported under
+const sarifRuleID = "PAN001"
+
+// ============================================================
+// SARIF DOCUMENT STRUCTURE (subset of SARIF 2.1.0)
+// ============================================================
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool        sarifTool         `json:"tool"`
+	Invocations []sarifInvocation `json:"invocations"`
+	Results     []sarifResult     `json:"results"`
+}
+
+type sarifTool struct {
+	Driver struct {
+		Name           string      `json:"name"`
+		Version        string      `json:"version,omitempty"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	} `json:"driver"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+	FullDescription  sarifMessage `json:"fullDescription"`
+	Help             sarifMessage `json:"help"`
+	DefaultLevel     struct {
+		Level string `json:"level"`
+	} `json:"defaultConfiguration"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifInvocation struct {
+	ExecutionSuccessful bool                `json:"executionSuccessful"`
+	StartTime           string              `json:"startTimeUtc"`
+	EndTime             string              `json:"endTimeUtc"`
+	Notifications       []sarifNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+type sarifNotification struct {
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+	Properties          map[string]string `json:"properties"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation struct {
+			URI string `json:"uri"`
+		} `json:"artifactLocation"`
+		Region *sarifRegion `json:"region,omitempty"`
+	} `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// SARIFExporter exports reports in SARIF 2.1.0 format
+// SARIF is ideal for:
+//   - GitHub / GitLab code scanning
+//   - CI pipelines that gate on findings
+//   - IDE integrations
+//
+// Every finding is one result of rule PAN001 with level "error".
+// Files that could not be scanned are reported as warnings of the
+// invocation, so CI can see the scan was incomplete.
+type SARIFExporter struct{}
+
+// Export implements the Exporter interface for SARIF format
+//
+// Parameters:
+//   - report: The report to export
+//   - filename: Output filename (should end with .sarif)
+//
+// Returns:
+//   - error: Error if file can't be written or JSON encoding fails
+func (e *SARIFExporter) Export(report *Report, filename string) error {
+	run := sarifRun{Results: []sarifResult{}}
+
+	driver := &run.Tool.Driver
+	driver.Name = "BasicPanScanner"
+	driver.Version = report.Version
+	driver.InformationURI = "https://github.com/keraattin/BasicPanScanner"
+
+	rule := sarifRule{ID: sarifRuleID, Name: "CardNumberExposure"}
+	rule.ShortDescription.Text = "Payment card number (PAN) stored in clear text"
+	rule.FullDescription.Text = "A number that passes the format, issuer (BIN) and Luhn checks was found. " +
+		"PCI DSS requirement 3.4 requires stored PANs to be unreadable (truncated, hashed, masked or encrypted)."
+	rule.Help.Text = "Remove the card number, or mask, truncate or encrypt it. " +
+		"Check backups and history: deleting the current copy is not enough."
+	rule.DefaultLevel.Level = "error"
+	driver.Rules = []sarifRule{rule}
+
+	// ============================================================
+	// Invocation (timing and unscanned files)
+	// ============================================================
+	invocation := sarifInvocation{
+		ExecutionSuccessful: true,
+		StartTime:           report.ScanDate.UTC().Format(time.RFC3339),
+		EndTime:             report.ScanDate.Add(report.Duration).UTC().Format(time.RFC3339),
+	}
+	for _, fileErr := range report.Errors {
+		notification := sarifNotification{
+			Level: "warning",
+			Message: sarifMessage{Text: fmt.Sprintf("Not scanned (%s, %s stage): %v",
+				fileErr.Class, fileErr.Stage, fileErr.Err)},
+		}
+		var location sarifLocation
+		location.PhysicalLocation.ArtifactLocation.URI = sarifURI(fileErr.Path)
+		notification.Locations = []sarifLocation{location}
+		invocation.Notifications = append(invocation.Notifications, notification)
+	}
+	run.Invocations = []sarifInvocation{invocation}
+
+	// ============================================================
+	// Results (sorted by file so the output is stable)
+	// ============================================================
+	filePaths := make([]string, 0, len(report.GroupedByFile))
+	for filePath := range report.GroupedByFile {
+		filePaths = append(filePaths, filePath)
+	}
+	sort.Strings(filePaths)
+
+	for _, filePath := range filePaths {
+		for _, finding := range report.GroupedByFile[filePath] {
+			run.Results = append(run.Results, sarifFinding(filePath, finding))
+		}
+	}
+
+	doc := sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, data, 0644)
+}
+
+// sarifFinding converts one finding to a SARIF result
+// Database findings get a logical location (schema.table.column) and no
+// line; git and S3 findings keep their commit / object version as
+// properties.
+func sarifFinding(filePath string, f scanner.Finding) sarifResult {
+	result := sarifResult{
+		RuleID: sarifRuleID,
+		Level:  "error",
+		Message: sarifMessage{Text: fmt.Sprintf("%s card number %s found (%s)",
+			f.CardType, f.MaskedCard, f.Location())},
+		Properties: map[string]string{
+			"cardType":   f.CardType,
+			"maskedCard": f.MaskedCard,
+		},
+	}
+
+	var location sarifLocation
+	location.PhysicalLocation.ArtifactLocation.URI = sarifURI(filePath)
+	if f.Table != "" {
+		location.LogicalLocations = []sarifLogicalLocation{{FullyQualifiedName: f.Location(), Kind: "member"}}
+	} else if f.LineNumber > 0 {
+		location.PhysicalLocation.Region = &sarifRegion{StartLine: f.LineNumber}
+	}
+	result.Locations = []sarifLocation{location}
+
+	if f.CommitSHA != "" {
+		result.Properties["commit"] = f.CommitSHA
+		result.Properties["author"] = f.CommitAuthor
+	}
+	if f.ObjectVersion != "" {
+		result.Properties["versionId"] = f.ObjectVersion
+	}
+
+	// Same card at the same place = same alert across scans
+	// (the masked card, not the PAN, so the fingerprint reveals nothing)
+	sum := sha256.Sum256([]byte(filePath + "\x00" + f.Location() + "\x00" + f.MaskedCard))
+	result.PartialFingerprints = map[string]string{"panLocation/v1": hex.EncodeToString(sum[:16])}
+
+	return result
+}
+
+// sarifURI converts a finding path to a SARIF artifact URI
+// Relative paths stay relative (code scanning resolves them against the
+// repository), absolute paths become file:// URIs and URLs (s3://) are
+// kept as they are.
+func sarifURI(path string) string {
+	if strings.Contains(path, "://") {
+		return path
+	}
+	slashed := filepath.ToSlash(path)
+	if filepath.IsAbs(path) {
+		if !strings.HasPrefix(slashed, "/") {
+			slashed = "/" + slashed // Windows: C:/x -> /C:/x
+		}
+		return (&url.URL{Scheme: "file", Path: slashed}).String()
+	}
+	return (&url.URL{Path: slashed}).String()
+}
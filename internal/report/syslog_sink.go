@@ -0,0 +1,403 @@
+// Package report - Syslog output (CEF / LEEF)
+// Sends findings to a SIEM as RFC 5424 syslog messages while the scan runs
+//
+// Each message carries a CEF (ArcSight, Splunk) or LEEF (QRadar) payload:
+//
+//	<108>1 2025-01-15T10:30:00.123456Z host BasicPanScanner 4242 finding - CEF:0|keraattin|BasicPanScanner|3.0.0|PAN001|Card number found|5|rt=... filePath=/var/log/app.log cs1Label=cardType cs1=Visa ...
+//
+// A scan sends one scan-start event, one finding event per card (masked)
+// and one scan-end event with the totals.
+package report
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/keraattin/BasicPanScanner/internal/scanner"
+)
+
+// Syslog payload formats
+const (
+	SyslogCEF  = "cef"
+	SyslogLEEF = "leef"
+)
+
+// syslogFacility is "log audit" (13): findings are compliance events
+const syslogFacility = 13
+
+// syslogTimeout bounds connecting and each write, so a stalled
+// collector can't hang the scan
+const syslogTimeout = 10 * time.Second
+
+// Event IDs (CEF Signature ID / LEEF Event ID)
+const (
+	syslogEventFinding   = "PAN001"
+	syslogEventScanStart = "SCAN_START"
+	syslogEventScanEnd   = "SCAN_END"
+)
+
+// syslogSeverity maps a risk level to RFC 5424 and CEF/LEEF severities
+// RFC 5424: 2 critical, 3 error, 4 warning, 6 informational
+// CEF/LEEF: 0-10, where 7-8 is high and 9-10 very high
+var syslogSeverity = map[string]struct{ syslog, cef int }{
+	RiskHigh:   {2, 9},
+	RiskMedium: {3, 7},
+	RiskLow:    {4, 5},
+	"":         {6, 1}, // Informational (scan start, scan end without cards)
+}
+
+// SyslogConfig configures a SyslogWriter
+type SyslogConfig struct {
+	// URL of the collector:
+	//   udp://host:514, tcp://host:514, tls://host:6514,
+	//   unix:///dev/log (datagram socket, stream socket as fallback)
+	URL string
+
+	// Format of the message payload: SyslogCEF (default) or SyslogLEEF
+	Format string
+
+	// CAFile is a PEM file with the CA that signed the collector's
+	// certificate (tls:// only; default: system roots)
+	CAFile string
+
+	// Version is the scanner version shown in the payload header
+	Version string
+}
+
+// SyslogWriter sends scan events to a syslog collector
+// It implements scanner.FindingSink, so findings are sent as soon as
+// their file is scanned.
+//
+// Example:
+//
+//	w, err := report.NewSyslogWriter(report.SyslogConfig{
+//	    URL:     "tcp://siem.example.com:514",
+//	    Format:  report.SyslogLEEF,
+//	    Version: "3.0.0",
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	w.ScanStarted("/srv")
+//	config.Sink = w
+//	result, _ := scanner.NewScanner(config).ScanDirectory("/srv")
+//	err = w.Close(report.NewReport("3.0.0", "/srv", "blacklist", nil, result))
+type SyslogWriter struct {
+	config   SyslogConfig
+	conn     net.Conn
+	stream   bool   // TCP, TLS and unix stream sockets need octet-counting framing
+	hostname string // RFC 5424 HOSTNAME
+	procID   string // RFC 5424 PROCID
+	worst    string // Highest file risk level seen (for the scan-end event)
+	sent     int    // Findings sent
+	err      error  // First send error (reported by Close)
+}
+
+// NewSyslogWriter connects to a syslog collector
+//
+// Parameters:
+//   - config: Collector URL, payload format and TLS settings
+//
+// Returns:
+//   - *SyslogWriter: Connected writer
+//   - error: Invalid URL or format, or the connection failed
+func NewSyslogWriter(config SyslogConfig) (*SyslogWriter, error) {
+	switch config.Format {
+	case "":
+		config.Format = SyslogCEF
+	case SyslogCEF, SyslogLEEF:
+	default:
+		return nil, fmt.Errorf("unknown syslog format '%s' (use cef or leef)", config.Format)
+	}
+
+	u, err := url.Parse(config.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid syslog URL: %w", err)
+	}
+
+	w := &SyslogWriter{
+		config: config,
+		procID: strconv.Itoa(os.Getpid()),
+	}
+	if w.hostname, err = os.Hostname(); err != nil || w.hostname == "" {
+		w.hostname = "-"
+	}
+
+	switch u.Scheme {
+	case "udp":
+		w.conn, err = net.DialTimeout("udp", u.Host, syslogTimeout)
+	case "tcp":
+		w.conn, err = net.DialTimeout("tcp", u.Host, syslogTimeout)
+		w.stream = true
+	case "tls":
+		w.conn, err = dialSyslogTLS(u.Host, config.CAFile)
+		w.stream = true
+	case "unix":
+		// /dev/log is a datagram socket; some daemons listen on a stream socket
+		if w.conn, err = net.DialTimeout("unixgram", u.Path, syslogTimeout); err != nil {
+			w.conn, err = net.DialTimeout("unix", u.Path, syslogTimeout)
+			w.stream = true
+		}
+	default:
+		return nil, fmt.Errorf("unsupported syslog URL '%s' (use udp://, tcp://, tls:// or unix://)", config.URL)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to syslog %s: %w", config.URL, err)
+	}
+	return w, nil
+}
+
+// dialSyslogTLS opens a TLS connection (RFC 5425)
+// caFile, if set, replaces the system roots for verifying the collector
+func dialSyslogTLS(address, caFile string) (net.Conn, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", caFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	dialer := &net.Dialer{Timeout: syslogTimeout}
+	return tls.DialWithDialer(dialer, "tcp", address, tlsConfig)
+}
+
+// Name returns the collector URL
+func (w *SyslogWriter) Name() string {
+	return w.config.URL
+}
+
+// Findings returns the number of findings sent so far
+func (w *SyslogWriter) Findings() int {
+	return w.sent
+}
+
+// ScanStarted sends the scan-start event
+//
+// Parameters:
+//   - target: What is scanned (directory, s3:// URL, repository...)
+func (w *SyslogWriter) ScanStarted(target string) {
+	w.send(syslogEvent{
+		id:    syslogEventScanStart,
+		name:  "Scan started",
+		msgID: "scan-start",
+		fields: []syslogField{
+			{key: "filePath", value: target},
+			{key: "msg", value: "Card data scan started"},
+		},
+	})
+}
+
+// WriteFindings sends one event per finding (scanner.FindingSink interface)
+// The severity comes from the file's risk level (its number of cards)
+func (w *SyslogWriter) WriteFindings(path string, findings []scanner.Finding) {
+	risk := FileRiskLevel(len(findings))
+	if w.worst == "" || syslogSeverity[risk].cef > syslogSeverity[w.worst].cef {
+		w.worst = risk
+	}
+
+	for _, f := range findings {
+		fields := []syslogField{
+			{key: "filePath", value: path},
+			{key: "fname", value: filepath.Base(path)},
+			{key: "cs1", label: "cardType", value: f.CardType},
+			{key: "cs2", label: "maskedCard", value: f.MaskedCard},
+			{key: "cs3", label: "location", value: f.Location()},
+			{key: "cs4", label: "riskLevel", value: risk},
+			{key: "cn1", label: "fileCardCount", value: strconv.Itoa(len(findings))},
+			{key: "msg", value: fmt.Sprintf("%s card number %s found (%s)", f.CardType, f.MaskedCard, f.Location())},
+		}
+		if f.CommitSHA != "" {
+			fields = append(fields, syslogField{key: "cs5", label: "commit", value: f.CommitSHA})
+		}
+
+		w.send(syslogEvent{
+			id:     syslogEventFinding,
+			name:   "Card number found",
+			risk:   risk,
+			msgID:  "finding",
+			time:   f.Timestamp,
+			fields: fields,
+		})
+		if w.err == nil {
+			w.sent++
+		}
+	}
+}
+
+// Close sends the scan-end event and closes the connection
+//
+// Parameters:
+//   - report: The finished scan (nil = scan failed, no scan-end event)
+//
+// Returns:
+//   - error: First error of any send, or of closing the connection
+func (w *SyslogWriter) Close(report *Report) error {
+	if report != nil {
+		risk := ""
+		if report.CardsFound > 0 {
+			risk = w.worst
+		}
+		w.send(syslogEvent{
+			id:    syslogEventScanEnd,
+			name:  "Scan finished",
+			risk:  risk,
+			msgID: "scan-end",
+			fields: []syslogField{
+				{key: "filePath", value: report.Directory},
+				{key: "cnt", value: strconv.Itoa(report.CardsFound)},
+				{key: "cs4", label: "riskLevel", value: risk},
+				{key: "cn1", label: "scannedFiles", value: strconv.Itoa(report.ScannedFiles)},
+				{key: "cn2", label: "unscannedFiles", value: strconv.Itoa(len(report.Errors))},
+				{key: "cn3", label: "durationMs", value: strconv.FormatInt(report.Duration.Milliseconds(), 10)},
+				{key: "msg", value: fmt.Sprintf("Card data scan finished: %d cards found", report.CardsFound)},
+			},
+		})
+	}
+
+	if err := w.conn.Close(); w.err == nil {
+		w.err = err
+	}
+	return w.err
+}
+
+// ============================================================
+// MESSAGE FORMATTING
+// ============================================================
+
+// syslogEvent is one message to send
+type syslogEvent struct {
+	id     string        // CEF Signature ID / LEEF Event ID
+	name   string        // CEF Name
+	risk   string        // Risk level ("" = informational)
+	msgID  string        // RFC 5424 MSGID
+	time   time.Time     // Event time (zero = now)
+	fields []syslogField // Payload fields, in order
+}
+
+// syslogField is one payload field
+// CEF custom fields (cs1, cn1...) get their label as a cs1Label=... pair;
+// LEEF uses the label as the key
+type syslogField struct {
+	key   string
+	label string
+	value string
+}
+
+// send formats and writes one message, remembering the first error
+func (w *SyslogWriter) send(event syslogEvent) {
+	if w.err != nil {
+		return
+	}
+	if event.time.IsZero() {
+		event.time = time.Now()
+	}
+
+	var payload string
+	if w.config.Format == SyslogLEEF {
+		payload = w.formatLEEF(event)
+	} else {
+		payload = w.formatCEF(event)
+	}
+
+	// RFC 5424: <PRI>VERSION TIMESTAMP HOSTNAME APP-NAME PROCID MSGID SD MSG
+	message := fmt.Sprintf("<%d>1 %s %s BasicPanScanner %s %s - %s",
+		syslogFacility*8+syslogSeverity[event.risk].syslog,
+		event.time.UTC().Format("2006-01-02T15:04:05.000000Z07:00"),
+		w.hostname, w.procID, event.msgID, payload)
+
+	// Stream transports frame each message with its length (RFC 6587 / 5425)
+	if w.stream {
+		message = strconv.Itoa(len(message)) + " " + message
+	}
+
+	w.conn.SetWriteDeadline(time.Now().Add(syslogTimeout))
+	_, w.err = w.conn.Write([]byte(message))
+}
+
+// formatCEF formats an event as ArcSight Common Event Format
+//
+//	CEF:0|Vendor|Product|Version|SignatureID|Name|Severity|Extension
+func (w *SyslogWriter) formatCEF(event syslogEvent) string {
+	header := strings.Join([]string{
+		"CEF:0",
+		cefHeader("keraattin"),
+		cefHeader("BasicPanScanner"),
+		cefHeader(w.config.Version),
+		cefHeader(event.id),
+		cefHeader(event.name),
+		strconv.Itoa(syslogSeverity[event.risk].cef),
+	}, "|")
+
+	extension := []string{"rt=" + strconv.FormatInt(event.time.UnixMilli(), 10)}
+	for _, field := range event.fields {
+		if field.value == "" {
+			continue
+		}
+		if field.label != "" {
+			extension = append(extension, field.key+"Label="+cefValue(field.label))
+		}
+		extension = append(extension, field.key+"="+cefValue(field.value))
+	}
+	return header + "|" + strings.Join(extension, " ")
+}
+
+// formatLEEF formats an event as IBM QRadar LEEF 1.0 (tab-separated)
+//
+//	LEEF:1.0|Vendor|Product|Version|EventID|key=value<TAB>key=value
+func (w *SyslogWriter) formatLEEF(event syslogEvent) string {
+	header := strings.Join([]string{
+		"LEEF:1.0",
+		cefHeader("keraattin"),
+		cefHeader("BasicPanScanner"),
+		cefHeader(w.config.Version),
+		cefHeader(event.id),
+	}, "|")
+
+	attributes := []string{
+		"devTime=" + event.time.UTC().Format("Jan 02 2006 15:04:05.000 MST"),
+		"devTimeFormat=MMM dd yyyy HH:mm:ss.SSS z",
+		"sev=" + strconv.Itoa(syslogSeverity[event.risk].cef),
+		"cat=" + event.msgID,
+	}
+	for _, field := range event.fields {
+		if field.value == "" {
+			continue
+		}
+		key := field.key
+		if field.label != "" {
+			key = field.label
+		}
+		attributes = append(attributes, key+"="+leefValue(field.value))
+	}
+	return header + "|" + strings.Join(attributes, "\t")
+}
+
+// cefHeader escapes a CEF/LEEF header field (backslash and pipe)
+func cefHeader(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "|", `\|`, "\n", " ", "\r", " ").Replace(value)
+}
+
+// cefValue escapes a CEF extension value (backslash, equals, newlines)
+func cefValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "=", `\=`, "\n", `\n`, "\r", `\r`).Replace(value)
+}
+
+// leefValue makes a LEEF attribute value safe (no tabs or newlines)
+func leefValue(value string) string {
+	return strings.NewReplacer("\t", " ", "\n", " ", "\r", " ").Replace(value)
+}
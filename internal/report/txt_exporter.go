@@ -44,12 +44,26 @@ func (e *TXTExporter) Export(report *Report, filename string) error {
 	content.WriteString("SCAN INFORMATION\n")
 	content.WriteString(strings.Repeat("─", 60) + "\n")
 	content.WriteString(fmt.Sprintf("Date:           %s\n", report.ScanDate.Format("2006-01-02 15:04:05")))
-	content.WriteString(fmt.Sprintf("Directory:      %s\n", report.Directory))
+	if roots := multipleRoots(report); roots != nil {
+		content.WriteString(fmt.Sprintf("Paths:          %d scanned\n", len(roots)))
+		for _, root := range roots {
+			content.WriteString(fmt.Sprintf("                %s\n", root))
+		}
+	} else {
+		content.WriteString(fmt.Sprintf("Directory:      %s\n", report.Directory))
+	}
 	content.WriteString(fmt.Sprintf("Duration:       %s\n", report.GetFormattedDuration())) // Use formatted duration
 	content.WriteString(fmt.Sprintf("Files Scanned:  %d / %d (%.1f%%)\n",
 		report.ScannedFiles,
 		report.TotalFiles,
 		float64(report.ScannedFiles)/float64(report.TotalFiles)*100))
+	for _, skip := range report.SkipCounts() {
+		content.WriteString(fmt.Sprintf("  Skipped (%s): %d\n", skip.Reason, skip.Count))
+	}
+	if report.BINDatabase.Checksum != "" {
+		content.WriteString(fmt.Sprintf("BIN Database:   v%s (%s)\n", report.BINDatabase.Version, report.BINDatabase.Source))
+		content.WriteString(fmt.Sprintf("                SHA-256 %s\n", report.BINDatabase.Checksum))
+	}
 	content.WriteString("\n")
 
 	// ============================================================
@@ -213,9 +227,15 @@ func (e *TXTExporter) Export(report *Report, filename string) error {
 					prefix = "└─"
 				}
 
-				content.WriteString(fmt.Sprintf("%s Line %4d: %-12s %s\n",
+				// Text files: "Line   12", databases: "users.pan (rowid 5)"
+				location := fmt.Sprintf("Line %4d", finding.LineNumber)
+				if finding.Table != "" {
+					location = finding.Location()
+				}
+
+				content.WriteString(fmt.Sprintf("%s %s: %-12s %s\n",
 					prefix,
-					finding.LineNumber,
+					location,
 					finding.CardType,
 					finding.MaskedCard))
 			}
@@ -225,6 +245,26 @@ func (e *TXTExporter) Export(report *Report, filename string) error {
 		content.WriteString("\nNo credit card numbers found. ✓\n\n")
 	}
 
+	// ============================================================
+	// UNSCANNED FILES (proof of coverage)
+	// ============================================================
+
+	content.WriteString("UNSCANNED FILES\n")
+	content.WriteString(strings.Repeat("─", 60) + "\n")
+	if len(report.Errors) > 0 {
+		for _, count := range report.ErrorCounts() {
+			content.WriteString(fmt.Sprintf("%-12s %d\n", count.Reason+":", count.Count))
+		}
+		content.WriteString("\n")
+		for _, fileErr := range report.Errors {
+			content.WriteString(fmt.Sprintf("[%s/%s] %s\n", fileErr.Class, fileErr.Stage, fileErr.Path))
+			content.WriteString(fmt.Sprintf("         %v\n", fileErr.Err))
+		}
+	} else {
+		content.WriteString("None - every selected file was scanned ✓\n")
+	}
+	content.WriteString("\n")
+
 	// ============================================================
 	// FOOTER
 	// ============================================================
@@ -0,0 +1,756 @@
+// Package report - Webhook notifications
+// Posts scan results to Slack, Microsoft Teams or any HTTP endpoint
+//
+// Two events can be sent:
+//   - scan.completed: when the scan is over, with the summary
+//     (Statistics), the top files and the overall risk level
+//   - scan.findings: while the scan runs, when files with cards are
+//     found (files that finish close together share one notification)
+//
+// Failed deliveries are retried with exponential backoff. With a secret,
+// every delivery is signed (HMAC-SHA256) so the receiver can check it
+// comes from the scanner.
+package report
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/keraattin/BasicPanScanner/internal/scanner"
+)
+
+// Webhook types (layout of the body)
+const (
+	WebhookGeneric = "generic" // WebhookPayload as JSON
+	WebhookSlack   = "slack"   // Slack incoming webhook
+	WebhookTeams   = "teams"   // Microsoft Teams incoming webhook (MessageCard)
+)
+
+// Webhook events (the payload's event is "scan.<event>")
+const (
+	WebhookCompleted = "completed" // Scan finished
+	WebhookFindings  = "findings"  // Files with cards found during the scan
+)
+
+// WebhookSchemaVersion is the version of the generic JSON payload
+// Increase it when a field changes meaning or is removed
+const WebhookSchemaVersion = 1
+
+// Headers sent with every delivery
+const (
+	WebhookEventHeader     = "X-PanScanner-Event"     // scan.completed or scan.findings
+	WebhookDeliveryHeader  = "X-PanScanner-Delivery"  // Random ID, the same on every retry
+	WebhookTimestampHeader = "X-PanScanner-Timestamp" // Unix seconds, part of the signature
+	WebhookSignatureHeader = "X-PanScanner-Signature" // "sha256=<hex>" (with a secret only)
+)
+
+// Delivery limits
+const (
+	webhookTimeout    = 10 * time.Second // One HTTP request
+	webhookMaxBackoff = 60 * time.Second // Longest wait between two attempts
+	webhookMessageTop = 5                // Files listed in Slack/Teams messages
+)
+
+// WebhookConfig configures a WebhookNotifier
+type WebhookConfig struct {
+	// URL of the endpoint (http:// or https://)
+	URL string
+
+	// Type of body: WebhookGeneric, WebhookSlack or WebhookTeams
+	// "" = from the URL (hooks.slack.com, *.webhook.office.com), else generic
+	Type string
+
+	// Events to send: WebhookCompleted and/or WebhookFindings
+	// (default: WebhookCompleted)
+	Events []string
+
+	// Secret signs every delivery with HMAC-SHA256 ("" = unsigned)
+	Secret string
+
+	// TemplateFile is a text/template that renders the body instead of
+	// the built-in one of Type. It is executed with a WebhookPayload.
+	TemplateFile string
+
+	// Retries is the number of extra attempts after a failed delivery
+	Retries int
+
+	// Backoff is the wait before the first retry, doubled after each
+	// one (default: 1s). A Retry-After header from the server wins.
+	Backoff time.Duration
+
+	// BatchDelay is how long scan.findings waits for more files before
+	// sending (default: 5s)
+	BatchDelay time.Duration
+
+	// Target is what is scanned (directory, s3:// URL, repository...)
+	Target string
+
+	// Version is the scanner version shown in the payload
+	Version string
+}
+
+// ============================================================
+// PAYLOAD
+// ============================================================
+
+// WebhookPayload is the body of a generic webhook and the data of a
+// body template
+//
+// Example (scan.completed):
+//
+//	{
+//	  "event": "scan.completed",
+//	  "schema_version": 1,
+//	  "scanner": "BasicPanScanner",
+//	  "version": "3.0.0",
+//	  "target": "/srv",
+//	  "risk_level": "Medium",
+//	  "message": "Card data scan of /srv finished: 10 cards in 5 files (risk: Medium)",
+//	  "summary": {"cards_found": 10, "files_with_cards": 5, ...},
+//	  "top_files": [{"path": "/srv/app.log", "card_count": 3, ...}]
+//	}
+type WebhookPayload struct {
+	Event         string          `json:"event"`
+	SchemaVersion int             `json:"schema_version"`
+	Scanner       string          `json:"scanner"`
+	Version       string          `json:"version"`
+	Host          string          `json:"host"`
+	Target        string          `json:"target"`
+	Time          string          `json:"time"`
+	RiskLevel     string          `json:"risk_level"`
+	Message       string          `json:"message"`             // One-line text
+	Summary       *WebhookSummary `json:"summary,omitempty"`   // scan.completed only
+	TopFiles      []WebhookFile   `json:"top_files,omitempty"` // scan.completed only
+	Files         []WebhookFile   `json:"files,omitempty"`     // scan.findings only: the new files
+}
+
+// WebhookSummary is the summary of a finished scan (from Statistics)
+type WebhookSummary struct {
+	CardsFound      int            `json:"cards_found"`
+	FilesWithCards  int            `json:"files_with_cards"`
+	TotalFiles      int            `json:"total_files"`
+	ScannedFiles    int            `json:"scanned_files"`
+	UnscannedFiles  int            `json:"unscanned_files"`
+	HighRiskFiles   int            `json:"high_risk_files"`
+	MediumRiskFiles int            `json:"medium_risk_files"`
+	LowRiskFiles    int            `json:"low_risk_files"`
+	CardsByType     map[string]int `json:"cards_by_type"`
+	Duration        string         `json:"duration"`
+	DurationNS      int64          `json:"duration_ns"`
+}
+
+// WebhookFile is a file with cards (no card numbers, not even masked)
+type WebhookFile struct {
+	Path      string         `json:"path"`
+	CardCount int            `json:"card_count"`
+	RiskLevel string         `json:"risk_level"`
+	CardTypes map[string]int `json:"card_types"`
+}
+
+// ============================================================
+// NOTIFIER
+// ============================================================
+
+// WebhookNotifier sends scan events to a webhook
+// It implements scanner.FindingSink: with WebhookFindings enabled, files
+// with cards are sent while the scan runs. A background goroutine does
+// the sending, so a slow or failing endpoint never holds up the scan.
+//
+// Example:
+//
+//	n, err := report.NewWebhookNotifier(report.WebhookConfig{
+//	    URL:     "https://hooks.slack.com/services/T000/B000/XXXX",
+//	    Retries: 3,
+//	    Target:  "/srv",
+//	    Version: "3.0.0",
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	config.Sink = n
+//	result, _ := scanner.NewScanner(config).ScanDirectory("/srv")
+//	err = n.Close(report.NewReport("3.0.0", "/srv", "blacklist", nil, result))
+type WebhookNotifier struct {
+	config      WebhookConfig
+	client      *http.Client
+	body        *template.Template // nil = built-in body of config.Type
+	name        string             // URL without its path (Slack and Teams keep the secret there)
+	hostname    string
+	onCompleted bool
+	onFindings  bool
+
+	// Files waiting for the next scan.findings notification
+	mu      sync.Mutex
+	pending []WebhookFile
+	index   map[string]int // Path -> position in pending
+	wake    chan struct{}  // A file was added
+	done    chan struct{}  // Scan is over: send what's left and stop
+	stopped chan struct{}  // Sender goroutine has returned
+
+	sent     int   // Notifications delivered
+	reported int   // Findings covered by delivered notifications
+	err      error // First failed delivery (reported by Close)
+}
+
+// NewWebhookNotifier checks the settings and prepares the notifier
+// Nothing is sent yet; no connection is made.
+//
+// Parameters:
+//   - config: Endpoint, events, signing and retry settings
+//
+// Returns:
+//   - *WebhookNotifier: Notifier ready for findings
+//   - error: Invalid URL, type, event or template
+func NewWebhookNotifier(config WebhookConfig) (*WebhookNotifier, error) {
+	u, err := url.Parse(config.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("invalid webhook URL (use http:// or https://)")
+	}
+
+	switch config.Type {
+	case "":
+		config.Type = webhookType(u)
+	case WebhookGeneric, WebhookSlack, WebhookTeams:
+	default:
+		return nil, fmt.Errorf("unknown webhook type '%s' (use generic, slack or teams)", config.Type)
+	}
+	if config.Backoff <= 0 {
+		config.Backoff = time.Second
+	}
+	if config.BatchDelay <= 0 {
+		config.BatchDelay = 5 * time.Second
+	}
+
+	n := &WebhookNotifier{
+		config: config,
+		client: &http.Client{Timeout: webhookTimeout},
+		name:   u.Scheme + "://" + u.Host + "/...",
+	}
+	if n.hostname, err = os.Hostname(); err != nil {
+		n.hostname = ""
+	}
+
+	if len(config.Events) == 0 {
+		config.Events = []string{WebhookCompleted}
+	}
+	for _, event := range config.Events {
+		switch strings.TrimSpace(event) {
+		case WebhookCompleted:
+			n.onCompleted = true
+		case WebhookFindings:
+			n.onFindings = true
+		default:
+			return nil, fmt.Errorf("unknown webhook event '%s' (use completed or findings)", event)
+		}
+	}
+
+	if config.TemplateFile != "" {
+		n.body, err = template.New(filepath.Base(config.TemplateFile)).
+			Funcs(template.FuncMap{"json": templateJSON}).
+			ParseFiles(config.TemplateFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load webhook template: %w", err)
+		}
+	}
+
+	if n.onFindings {
+		n.index = make(map[string]int)
+		n.wake = make(chan struct{}, 1)
+		n.done = make(chan struct{})
+		n.stopped = make(chan struct{})
+		go n.run()
+	}
+	return n, nil
+}
+
+// webhookType guesses the body type from the endpoint's host
+func webhookType(u *url.URL) string {
+	host := strings.ToLower(u.Hostname())
+	switch {
+	case host == "hooks.slack.com":
+		return WebhookSlack
+	case strings.HasSuffix(host, ".webhook.office.com"), host == "outlook.office.com":
+		return WebhookTeams
+	}
+	return WebhookGeneric
+}
+
+// templateJSON is the "json" template function: a value as JSON
+// Use it to put text into a JSON body safely: {"text": {{json .Message}}}
+func templateJSON(value interface{}) (string, error) {
+	data, err := json.Marshal(value)
+	return string(data), err
+}
+
+// Name returns the endpoint, without the path (it may hold a secret)
+func (n *WebhookNotifier) Name() string {
+	return n.name
+}
+
+// Findings returns the number of findings covered by the notifications
+// delivered so far
+func (n *WebhookNotifier) Findings() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.reported
+}
+
+// Sent returns the number of notifications delivered so far
+func (n *WebhookNotifier) Sent() int {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.sent
+}
+
+// WriteFindings queues a file for the next scan.findings notification
+// (scanner.FindingSink interface). It never waits for the network.
+func (n *WebhookNotifier) WriteFindings(path string, findings []scanner.Finding) {
+	if !n.onFindings {
+		return
+	}
+
+	n.mu.Lock()
+	i, ok := n.index[path]
+	if !ok {
+		// A git path can have findings in several commits: one entry
+		i = len(n.pending)
+		n.index[path] = i
+		n.pending = append(n.pending, WebhookFile{Path: path, CardTypes: make(map[string]int)})
+	}
+	file := &n.pending[i]
+	file.CardCount += len(findings)
+	file.RiskLevel = FileRiskLevel(file.CardCount)
+	for _, f := range findings {
+		file.CardTypes[f.CardType]++
+	}
+	n.mu.Unlock()
+
+	select {
+	case n.wake <- struct{}{}:
+	default: // Already woken
+	}
+}
+
+// run sends scan.findings notifications until Close
+// After the first file it waits BatchDelay, so a burst of files is one
+// notification rather than one per file.
+func (n *WebhookNotifier) run() {
+	defer close(n.stopped)
+	for {
+		select {
+		case <-n.wake:
+		case <-n.done:
+			n.sendFindings()
+			return
+		}
+
+		select {
+		case <-time.After(n.config.BatchDelay):
+		case <-n.done:
+		}
+		n.sendFindings()
+	}
+}
+
+// sendFindings sends the queued files as one scan.findings notification
+func (n *WebhookNotifier) sendFindings() {
+	n.mu.Lock()
+	files := n.pending
+	n.pending = nil
+	n.index = make(map[string]int)
+	n.mu.Unlock()
+
+	if len(files) == 0 {
+		return
+	}
+
+	cards := 0
+	risk := RiskLow
+	for _, file := range files {
+		cards += file.CardCount
+		if file.RiskLevel == RiskHigh || (file.RiskLevel == RiskMedium && risk == RiskLow) {
+			risk = file.RiskLevel
+		}
+	}
+
+	payload := n.newPayload(WebhookFindings, risk)
+	payload.Target = n.config.Target
+	payload.Files = files
+	payload.Message = fmt.Sprintf("%d new cards found in %d files under %s", cards, len(files), n.config.Target)
+	n.notify(payload, cards, false)
+}
+
+// Close sends the last notifications and stops the notifier
+// Files still queued are sent first (even if the scan failed), then
+// scan.completed.
+//
+// Parameters:
+//   - report: The finished scan (nil = scan failed, no scan.completed)
+//
+// Returns:
+//   - error: First delivery that failed after all retries
+func (n *WebhookNotifier) Close(report *Report) error {
+	if n.onFindings {
+		close(n.done)
+		<-n.stopped
+	}
+	if report != nil && n.onCompleted {
+		n.notify(n.completedPayload(report), report.CardsFound, true)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.err
+}
+
+// completedPayload builds the scan.completed payload of a report
+func (n *WebhookNotifier) completedPayload(report *Report) WebhookPayload {
+	stats := report.Statistics
+	level, _ := report.GetRiskLevel()
+
+	payload := n.newPayload(WebhookCompleted, level)
+	payload.Target = report.Directory
+	payload.Summary = &WebhookSummary{
+		CardsFound:      report.CardsFound,
+		FilesWithCards:  stats.FilesWithCards,
+		TotalFiles:      report.TotalFiles,
+		ScannedFiles:    report.ScannedFiles,
+		UnscannedFiles:  len(report.Errors),
+		HighRiskFiles:   stats.HighRiskFiles,
+		MediumRiskFiles: stats.MediumRiskFiles,
+		LowRiskFiles:    stats.LowRiskFiles,
+		CardsByType:     stats.CardsByType,
+		Duration:        report.GetFormattedDuration(),
+		DurationNS:      int64(report.Duration),
+	}
+	for _, file := range stats.TopFiles {
+		payload.TopFiles = append(payload.TopFiles, WebhookFile{
+			Path:      file.FilePath,
+			CardCount: file.CardCount,
+			RiskLevel: FileRiskLevel(file.CardCount),
+			CardTypes: file.CardTypes,
+		})
+	}
+
+	if report.CardsFound == 0 {
+		payload.Message = fmt.Sprintf("Card data scan of %s finished: no cards found", report.Directory)
+	} else {
+		payload.Message = fmt.Sprintf("Card data scan of %s finished: %d cards in %d files (risk: %s)",
+			report.Directory, report.CardsFound, stats.FilesWithCards, level)
+	}
+	return payload
+}
+
+// newPayload fills the fields every event has
+func (n *WebhookNotifier) newPayload(event, risk string) WebhookPayload {
+	return WebhookPayload{
+		Event:         "scan." + event,
+		SchemaVersion: WebhookSchemaVersion,
+		Scanner:       "BasicPanScanner",
+		Version:       n.config.Version,
+		Host:          n.hostname,
+		Time:          time.Now().UTC().Format(jsonTimeFormat),
+		RiskLevel:     risk,
+	}
+}
+
+// ============================================================
+// DELIVERY
+// ============================================================
+
+// notify renders and delivers a payload, remembering the first failure
+//
+// Parameters:
+//   - payload: Event to send
+//   - cards: Findings the event covers (for Findings)
+//   - total: cards is the scan's total, not an addition
+func (n *WebhookNotifier) notify(payload WebhookPayload, cards int, total bool) {
+	body, contentType, err := n.render(payload)
+	if err == nil {
+		err = n.post(payload.Event, body, contentType)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if err != nil {
+		if n.err == nil {
+			n.err = fmt.Errorf("%s: %w", payload.Event, err)
+		}
+		return
+	}
+	n.sent++
+	if total {
+		n.reported = cards
+	} else {
+		n.reported += cards
+	}
+}
+
+// render builds the request body of a payload
+//
+// Returns:
+//   - []byte: Body
+//   - string: Content type (JSON if the body is valid JSON, else text)
+//   - error: Template or encoding error
+func (n *WebhookNotifier) render(payload WebhookPayload) ([]byte, string, error) {
+	var body []byte
+	var err error
+
+	switch {
+	case n.body != nil:
+		var buf bytes.Buffer
+		if err = n.body.Execute(&buf, payload); err != nil {
+			return nil, "", fmt.Errorf("webhook template: %w", err)
+		}
+		body = buf.Bytes()
+	case n.config.Type == WebhookSlack:
+		body, err = json.Marshal(slackMessage(payload))
+	case n.config.Type == WebhookTeams:
+		body, err = json.Marshal(teamsMessage(payload))
+	default:
+		body, err = json.Marshal(payload)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	if json.Valid(body) {
+		return body, "application/json", nil
+	}
+	return body, "text/plain; charset=utf-8", nil
+}
+
+// post delivers a body, retrying with exponential backoff
+// Network errors, 408, 429 and 5xx answers are retried; other answers
+// (a wrong URL, a rejected body) are not.
+func (n *WebhookNotifier) post(event string, body []byte, contentType string) error {
+	delivery := newDeliveryID()
+	wait := n.config.Backoff
+
+	for attempt := 1; ; attempt++ {
+		retry, retryAfter, err := n.attempt(event, delivery, body, contentType)
+		if err == nil {
+			return nil
+		}
+		if !retry || attempt > n.config.Retries {
+			if attempt > 1 {
+				return fmt.Errorf("%w (gave up after %d attempts)", err, attempt)
+			}
+			return err
+		}
+
+		if retryAfter > 0 {
+			wait = retryAfter
+		}
+		if wait > webhookMaxBackoff {
+			wait = webhookMaxBackoff
+		}
+		time.Sleep(wait)
+		wait *= 2
+	}
+}
+
+// attempt sends one HTTP request
+//
+// Returns:
+//   - bool: The failure is worth retrying
+//   - time.Duration: Wait asked by the server (Retry-After), 0 = none
+//   - error: nil if the server answered 2xx
+func (n *WebhookNotifier) attempt(event, delivery string, body []byte, contentType string) (bool, time.Duration, error) {
+	req, err := http.NewRequest(http.MethodPost, n.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, 0, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("User-Agent", "BasicPanScanner/"+n.config.Version)
+	req.Header.Set(WebhookEventHeader, event)
+	req.Header.Set(WebhookDeliveryHeader, delivery)
+	req.Header.Set(WebhookTimestampHeader, timestamp)
+	if n.config.Secret != "" {
+		req.Header.Set(WebhookSignatureHeader, "sha256="+WebhookSignature(n.config.Secret, timestamp, body))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		// url.Error repeats the URL, which holds Slack's and Teams' secret
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return true, 0, err
+	}
+	defer resp.Body.Close()
+
+	// Slack and Teams explain a rejected body in a short text answer
+	answer, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10)) // Lets the connection be reused
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, 0, nil
+	}
+
+	err = fmt.Errorf("server answered %s", resp.Status)
+	if text := strings.TrimSpace(string(answer)); text != "" && len(text) < 200 {
+		err = fmt.Errorf("server answered %s: %s", resp.Status, text)
+	}
+
+	retry := resp.StatusCode == http.StatusRequestTimeout ||
+		resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode >= 500
+	return retry, parseRetryAfter(resp.Header.Get("Retry-After")), err
+}
+
+// parseRetryAfter reads a Retry-After header (seconds or an HTTP date)
+// Returns 0 if the header is missing or invalid
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := time.Until(date); wait > 0 {
+			return wait
+		}
+	}
+	return 0
+}
+
+// WebhookSignature computes the signature of a delivery
+// It is the hex HMAC-SHA256 of "<timestamp>.<body>" keyed with the
+// secret. The timestamp is signed too, so a receiver that rejects old
+// timestamps can't be fooled by a replayed delivery.
+//
+// Parameters:
+//   - secret: Shared secret
+//   - timestamp: Value of the X-PanScanner-Timestamp header
+//   - body: Request body, exactly as received
+//
+// Returns:
+//   - string: Hex signature (the header is "sha256=" + this)
+//
+// Example (receiver):
+//
+//	want := "sha256=" + report.WebhookSignature(secret, r.Header.Get(report.WebhookTimestampHeader), body)
+//	if !hmac.Equal([]byte(want), []byte(r.Header.Get(report.WebhookSignatureHeader))) {
+//	    http.Error(w, "bad signature", http.StatusUnauthorized)
+//	}
+func WebhookSignature(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// newDeliveryID returns a random delivery ID (32 hex characters)
+// Retries reuse it, so a receiver can drop duplicates.
+func newDeliveryID() string {
+	var id [16]byte
+	rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
+
+// ============================================================
+// SLACK AND TEAMS MESSAGES
+// ============================================================
+
+// slackMessageBody is the body of a Slack incoming webhook
+type slackMessageBody struct {
+	Text string `json:"text"`
+}
+
+// teamsMessageCard is the body of a Teams incoming webhook (MessageCard)
+type teamsMessageCard struct {
+	Type       string `json:"@type"`
+	Context    string `json:"@context"`
+	ThemeColor string `json:"themeColor"`
+	Summary    string `json:"summary"`
+	Title      string `json:"title"`
+	Text       string `json:"text"`
+}
+
+// slackMessage builds a Slack message: bold title, then the details
+func slackMessage(payload WebhookPayload) slackMessageBody {
+	lines := append([]string{"*" + payload.Message + "*"}, webhookDetails(payload)...)
+	return slackMessageBody{Text: strings.Join(lines, "\n")}
+}
+
+// teamsMessage builds a Teams card colored by risk level
+// Teams markdown needs a blank line to break a line
+func teamsMessage(payload WebhookPayload) teamsMessageCard {
+	return teamsMessageCard{
+		Type:       "MessageCard",
+		Context:    "https://schema.org/extensions",
+		ThemeColor: strings.TrimPrefix(RiskColor(payload.RiskLevel), "#"),
+		Summary:    payload.Message,
+		Title:      payload.Message,
+		Text:       strings.Join(webhookDetails(payload), "\n\n"),
+	}
+}
+
+// webhookDetails returns the detail lines of a chat message:
+// totals, cards by type and the files with the most cards
+func webhookDetails(payload WebhookPayload) []string {
+	var lines []string
+	files := payload.Files
+
+	if summary := payload.Summary; summary != nil {
+		lines = append(lines, fmt.Sprintf("Risk level: %s | Scanned files: %d | Not scanned: %d | Duration: %s",
+			payload.RiskLevel, summary.ScannedFiles, summary.UnscannedFiles, summary.Duration))
+		if len(summary.CardsByType) > 0 {
+			lines = append(lines, "Cards by type: "+formatCardTypes(summary.CardsByType))
+		}
+		files = payload.TopFiles
+	}
+
+	for i, file := range files {
+		if i == webhookMessageTop {
+			lines = append(lines, fmt.Sprintf("...and %d more files", len(files)-i))
+			break
+		}
+		lines = append(lines, fmt.Sprintf("• `%s`: %d cards (%s risk; %s)",
+			file.Path, file.CardCount, file.RiskLevel, formatCardTypes(file.CardTypes)))
+	}
+	return lines
+}
+
+// formatCardTypes formats card counts by type, largest first
+// Example: "Visa 6, Mastercard 4"
+func formatCardTypes(cardTypes map[string]int) string {
+	names := make([]string, 0, len(cardTypes))
+	for name := range cardTypes {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if cardTypes[names[i]] != cardTypes[names[j]] {
+			return cardTypes[names[i]] > cardTypes[names[j]]
+		}
+		return names[i] < names[j]
+	})
+
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s %d", name, cardTypes[name])
+	}
+	return strings.Join(parts, ", ")
+}
@@ -61,7 +61,17 @@ func (e *XMLExporter) Export(report *Report, filename string) error {
 	}
 
 	type XMLFinding struct {
-		LineNumber int    `xml:"LineNumber"`
+		LineNumber int    `xml:"LineNumber,omitempty"`
+		Schema     string `xml:"Schema,omitempty"`
+		Table      string `xml:"Table,omitempty"`
+		Column     string `xml:"Column,omitempty"`
+		RowID      int64  `xml:"RowID,omitempty"`
+		PrimaryKey string `xml:"PrimaryKey,omitempty"`
+		Commit     string `xml:"Commit,omitempty"`
+		Author     string `xml:"Author,omitempty"`
+		CommitDate string `xml:"CommitDate,omitempty"`
+		VersionID  string `xml:"VersionID,omitempty"`
+		ETag       string `xml:"ETag,omitempty"`
 		CardType   string `xml:"CardType"`
 		MaskedCard string `xml:"MaskedCard"`
 		Timestamp  string `xml:"Timestamp"`
@@ -73,17 +83,44 @@ func (e *XMLExporter) Export(report *Report, filename string) error {
 		Findings []XMLFinding `xml:"Finding"`
 	}
 
+	type XMLSkip struct {
+		Reason string `xml:"reason,attr"`
+		Count  int    `xml:",chardata"`
+	}
+
+	type XMLUnscannedFile struct {
+		Path    string `xml:"path,attr"`
+		Stage   string `xml:"stage,attr"`
+		Class   string `xml:"class,attr"`
+		Message string `xml:",chardata"`
+	}
+
+	type XMLUnscanned struct {
+		Count int                `xml:"count,attr"`
+		Files []XMLUnscannedFile `xml:"File"`
+	}
+
+	type XMLBINDatabase struct {
+		Version  string `xml:"version,attr"`
+		Source   string `xml:"source,attr"`
+		Checksum string `xml:"sha256,attr"`
+	}
+
 	type XMLReport struct {
-		XMLName      xml.Name `xml:"ScanReport"`
-		Version      string   `xml:"version,attr"`
-		ScanDate     string   `xml:"ScanInfo>ScanDate"`
-		Directory    string   `xml:"ScanInfo>Directory"`
-		Duration     string   `xml:"ScanInfo>Duration"`
-		TotalFiles   int      `xml:"ScanInfo>TotalFiles"`
-		ScannedFiles int      `xml:"ScanInfo>ScannedFiles"`
-		TotalCards   int      `xml:"Summary>TotalCards"`
+		XMLName      xml.Name        `xml:"ScanReport"`
+		Version      string          `xml:"version,attr"`
+		ScanDate     string          `xml:"ScanInfo>ScanDate"`
+		Directory    string          `xml:"ScanInfo>Directory"`
+		Roots        []string        `xml:"ScanInfo>Roots>Root,omitempty"`
+		Duration     string          `xml:"ScanInfo>Duration"`
+		TotalFiles   int             `xml:"ScanInfo>TotalFiles"`
+		ScannedFiles int             `xml:"ScanInfo>ScannedFiles"`
+		Skipped      []XMLSkip       `xml:"ScanInfo>Skipped>Skip,omitempty"`
+		BINDatabase  *XMLBINDatabase `xml:"ScanInfo>BINDatabase,omitempty"`
+		TotalCards   int             `xml:"Summary>TotalCards"`
 		Statistics   XMLStatistics
 		FileGroups   []XMLFileGroup `xml:"Findings>FileGroup"`
+		Unscanned    XMLUnscanned   `xml:"UnscannedFiles"`
 	}
 
 	// ============================================================
@@ -134,6 +171,23 @@ func (e *XMLExporter) Export(report *Report, filename string) error {
 		})
 	}
 
+	// Convert skip counts
+	var skipped []XMLSkip
+	for _, skip := range report.SkipCounts() {
+		skipped = append(skipped, XMLSkip{Reason: skip.Reason, Count: skip.Count})
+	}
+
+	// Convert unscanned files
+	unscanned := XMLUnscanned{Count: len(report.Errors)}
+	for _, fileErr := range report.Errors {
+		unscanned.Files = append(unscanned.Files, XMLUnscannedFile{
+			Path:    fileErr.Path,
+			Stage:   fileErr.Stage,
+			Class:   string(fileErr.Class),
+			Message: fileErr.Err.Error(),
+		})
+	}
+
 	// Convert findings
 	var fileGroups []XMLFileGroup
 
@@ -151,6 +205,16 @@ func (e *XMLExporter) Export(report *Report, filename string) error {
 		for _, f := range findings {
 			xmlFindings = append(xmlFindings, XMLFinding{
 				LineNumber: f.LineNumber,
+				Schema:     f.Schema,
+				Table:      f.Table,
+				Column:     f.Column,
+				RowID:      f.RowID,
+				PrimaryKey: f.PrimaryKey,
+				Commit:     f.CommitSHA,
+				Author:     f.CommitAuthor,
+				CommitDate: commitDate(f),
+				VersionID:  f.ObjectVersion,
+				ETag:       f.ETag,
 				CardType:   f.CardType,
 				MaskedCard: f.MaskedCard,
 				Timestamp:  f.Timestamp.Format("2006-01-02T15:04:05Z07:00"),
@@ -168,13 +232,25 @@ func (e *XMLExporter) Export(report *Report, filename string) error {
 	// Build XML report structure
 	// ============================================================
 
+	var binDatabase *XMLBINDatabase
+	if report.BINDatabase.Checksum != "" {
+		binDatabase = &XMLBINDatabase{
+			Version:  report.BINDatabase.Version,
+			Source:   report.BINDatabase.Source,
+			Checksum: report.BINDatabase.Checksum,
+		}
+	}
+
 	xmlReport := XMLReport{
 		Version:      report.Version,
 		ScanDate:     report.ScanDate.Format("2006-01-02T15:04:05Z07:00"),
 		Directory:    report.Directory,
+		Roots:        multipleRoots(report),
 		Duration:     report.GetFormattedDuration(), // Use formatted duration
 		TotalFiles:   report.TotalFiles,
 		ScannedFiles: report.ScannedFiles,
+		Skipped:      skipped,
+		BINDatabase:  binDatabase,
 		TotalCards:   report.CardsFound,
 		Statistics: XMLStatistics{
 			CardsByType:     cardTypes,
@@ -186,6 +262,7 @@ func (e *XMLExporter) Export(report *Report, filename string) error {
 			LowRiskFiles:    report.Statistics.LowRiskFiles,
 		},
 		FileGroups: fileGroups,
+		Unscanned:  unscanned,
 	}
 
 	// ============================================================
@@ -0,0 +1,387 @@
+// Package scanner - Content-Based File Type Detection
+// File: internal/scanner/content_sniffer.go
+//
+// This file identifies files by their content (magic numbers) instead of
+// their name. Renamed exports ("export.dat" that is really XLSX, a PDF
+// with no extension) are routed to the right reader, and binaries named
+// ".txt" can be skipped instead of being read as text.
+//
+// DETECTED TYPES:
+//
+//	ZIP containers   OOXML (DOCX/XLSX/PPTX) via [Content_Types].xml parts,
+//	                 ODF (ODT/ODS/ODP) via the "mimetype" entry,
+//	                 other ZIP archives
+//	%PDF-            PDF
+//	D0 CF 11 E0      OLE2 compound file (legacy .doc/.xls/.msg - no reader)
+//	1F 8B            gzip (decompressed and scanned as text)
+//	SQLite format 3  SQLite database
+//	FF FE / FE FF    UTF-16 text with BOM (also detected without BOM)
+//	NUL bytes or
+//	mostly control   binary
+//	anything else    text
+//
+// Only the first 8KB are inspected (plus the ZIP central directory).
+package scanner
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf16"
+)
+
+// ============================================================
+// FILE KINDS
+// ============================================================
+
+// fileKind is the content type detected for a file
+type fileKind int
+
+const (
+	kindText    fileKind = iota // Plain text (UTF-8, ASCII, Latin-1, ...)
+	kindUTF16LE                 // UTF-16 little endian text
+	kindUTF16BE                 // UTF-16 big endian text
+	kindBinary                  // Executables, images, media, unknown binary
+	kindPDF                     // PDF document
+	kindGzip                    // gzip-compressed data
+	kindSQLite                  // SQLite 3 database
+	kindOLE2                    // OLE2 compound file (legacy Office)
+	kindZIP                     // ZIP archive that isn't an office document
+	kindDOCX                    // Word 2007+
+	kindXLSX                    // Excel 2007+
+	kindPPTX                    // PowerPoint 2007+
+	kindODT                     // OpenDocument text
+	kindODS                     // OpenDocument spreadsheet
+	kindODP                     // OpenDocument presentation
+)
+
+// String returns a short name for summaries and warnings
+func (k fileKind) String() string {
+	switch k {
+	case kindText:
+		return "text"
+	case kindUTF16LE:
+		return "UTF-16LE text"
+	case kindUTF16BE:
+		return "UTF-16BE text"
+	case kindBinary:
+		return "binary"
+	case kindPDF:
+		return "PDF"
+	case kindGzip:
+		return "gzip"
+	case kindSQLite:
+		return "SQLite"
+	case kindOLE2:
+		return "OLE2"
+	case kindZIP:
+		return "ZIP"
+	case kindDOCX:
+		return "DOCX"
+	case kindXLSX:
+		return "XLSX"
+	case kindPPTX:
+		return "PPTX"
+	case kindODT:
+		return "ODT"
+	case kindODS:
+		return "ODS"
+	case kindODP:
+		return "ODP"
+	default:
+		return "unknown"
+	}
+}
+
+// scannable reports whether we have a reader for this kind
+func (k fileKind) scannable() bool {
+	switch k {
+	case kindBinary, kindOLE2, kindZIP:
+		return false
+	default:
+		return true
+	}
+}
+
+// sniffSize is how many leading bytes are inspected
+const sniffSize = 8192
+
+// ============================================================
+// DETECTION
+// ============================================================
+
+// sniffFile detects the content type of a file
+//
+// Parameters:
+//   - filePath: File to inspect
+//
+// Returns:
+//   - fileKind: Detected type
+//   - error: Error if the file can't be read
+//
+// Example:
+//
+//	kind, err := sniffFile("/exports/export.dat")
+//	// kind == kindXLSX for a renamed Excel workbook
+func sniffFile(filePath string) (fileKind, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return kindBinary, err
+	}
+	defer file.Close()
+
+	header := make([]byte, sniffSize)
+	n, err := io.ReadFull(file, header)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return kindBinary, err
+	}
+	header = header[:n]
+
+	kind := sniffBytes(header)
+	if kind == kindZIP {
+		kind = sniffZIP(filePath)
+	}
+	return kind, nil
+}
+
+// sniffBytes detects the content type from the first bytes of a file
+// ZIP containers are returned as kindZIP (see sniffZIP for the subtype)
+func sniffBytes(header []byte) fileKind {
+	switch {
+	case bytes.HasPrefix(header, []byte("PK\x03\x04")), bytes.HasPrefix(header, []byte("PK\x05\x06")):
+		return kindZIP
+	case bytes.HasPrefix(header, []byte("%PDF-")):
+		return kindPDF
+	case bytes.HasPrefix(header, []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}):
+		return kindOLE2
+	case bytes.HasPrefix(header, []byte{0x1F, 0x8B}):
+		return kindGzip
+	case bytes.HasPrefix(header, []byte(sqliteMagic)):
+		return kindSQLite
+	case bytes.HasPrefix(header, []byte{0xFF, 0xFE}):
+		return kindUTF16LE
+	case bytes.HasPrefix(header, []byte{0xFE, 0xFF}):
+		return kindUTF16BE
+	}
+
+	return sniffTextOrBinary(header)
+}
+
+// sniffTextOrBinary applies the binary heuristics to content without magic
+//
+// Rules:
+//   - Mostly-zero odd (or even) bytes with printable others: UTF-16 without BOM
+//   - Any other NUL byte: binary (what git and grep do)
+//   - More than 10% control characters: binary
+//   - Otherwise: text
+func sniffTextOrBinary(data []byte) fileKind {
+	if len(data) == 0 {
+		return kindText
+	}
+
+	if bytes.IndexByte(data, 0) < 0 {
+		control := 0
+		for _, c := range data {
+			if c < 0x20 && c != '\n' && c != '\r' && c != '\t' && c != '\f' && c != 0x1B {
+				control++
+			}
+		}
+		if control*10 > len(data) {
+			return kindBinary
+		}
+		return kindText
+	}
+
+	// NUL bytes: either UTF-16 text (ASCII range) or binary
+	if len(data) >= 4 {
+		zeroEven, zeroOdd := 0, 0
+		for i := 0; i+1 < len(data); i += 2 {
+			if data[i] == 0 {
+				zeroEven++
+			}
+			if data[i+1] == 0 {
+				zeroOdd++
+			}
+		}
+		pairs := len(data) / 2
+		if zeroOdd*10 >= pairs*9 && zeroEven*10 < pairs {
+			return kindUTF16LE
+		}
+		if zeroEven*10 >= pairs*9 && zeroOdd*10 < pairs {
+			return kindUTF16BE
+		}
+	}
+
+	return kindBinary
+}
+
+// sniffZIP tells office documents apart from other ZIP archives
+//
+// OOXML: [Content_Types].xml plus a word/, xl/ or ppt/ part
+// ODF:   a "mimetype" entry naming the OpenDocument type
+func sniffZIP(filePath string) fileKind {
+	reader, err := zip.OpenReader(filePath)
+	if err != nil {
+		return kindZIP
+	}
+	defer reader.Close()
+
+	hasContentTypes := false
+	kind := kindZIP
+
+	for _, entry := range reader.File {
+		name := entry.Name
+		switch {
+		case name == "[Content_Types].xml":
+			hasContentTypes = true
+		case strings.HasPrefix(name, "word/"):
+			kind = kindDOCX
+		case strings.HasPrefix(name, "xl/"):
+			kind = kindXLSX
+		case strings.HasPrefix(name, "ppt/"):
+			kind = kindPPTX
+		case name == "mimetype":
+			if odf := sniffODFMimetype(entry); odf != kindZIP {
+				return odf
+			}
+		}
+	}
+
+	if !hasContentTypes {
+		return kindZIP
+	}
+	return kind
+}
+
+// sniffODFMimetype reads the "mimetype" entry of an OpenDocument file
+func sniffODFMimetype(entry *zip.File) fileKind {
+	rc, err := entry.Open()
+	if err != nil {
+		return kindZIP
+	}
+	defer rc.Close()
+
+	mimetype, _ := io.ReadAll(io.LimitReader(rc, 128))
+	switch strings.TrimSpace(string(mimetype)) {
+	case "application/vnd.oasis.opendocument.text":
+		return kindODT
+	case "application/vnd.oasis.opendocument.spreadsheet":
+		return kindODS
+	case "application/vnd.oasis.opendocument.presentation":
+		return kindODP
+	}
+	return kindZIP
+}
+
+// ============================================================
+// READERS FOR SNIFFED TYPES
+// ============================================================
+
+// readByKind extracts the text of a file using the reader for its kind
+// SQLite is not handled here (it produces findings directly)
+//
+// Parameters:
+//   - ctx: Per-file context (timeout and memory budget)
+//   - filePath: File to read
+//   - kind: Type detected by sniffFile
+//   - maxSize: Decompressed size limit for gzip (0 = no limit)
+//
+// Returns:
+//   - string: Text to scan
+//   - error: Error if the file can't be read or parsed
+func readByKind(ctx context.Context, filePath string, kind fileKind, maxSize int64) (string, error) {
+	switch kind {
+	case kindPDF:
+		return readPDF(ctx, filePath)
+	case kindDOCX:
+		return readDOCX(ctx, filePath)
+	case kindXLSX:
+		return readXLSX(ctx, filePath)
+	case kindPPTX:
+		return readPPTX(ctx, filePath)
+	case kindODT:
+		return readODT(ctx, filePath)
+	case kindODS:
+		return readODS(ctx, filePath)
+	case kindODP:
+		return readODP(ctx, filePath)
+	case kindGzip:
+		return readGzipText(ctx, filePath, maxSize)
+	}
+
+	content, err := readFileLimited(ctx, filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read file: %w", err)
+	}
+
+	switch kind {
+	case kindUTF16LE:
+		return decodeUTF16(content, false), nil
+	case kindUTF16BE:
+		return decodeUTF16(content, true), nil
+	}
+	return string(content), nil
+}
+
+// readGzipText decompresses a gzip file and returns its text
+// Compressed binaries (e.g. .tar.gz) return "" - there's no text to scan
+func readGzipText(ctx context.Context, filePath string, maxSize int64) (string, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return "", fmt.Errorf("invalid gzip data: %w", err)
+	}
+	defer gz.Close()
+
+	var reader io.Reader = gz
+	if maxSize > 0 {
+		// Guard against decompression bombs (one extra byte tells
+		// whether the limit was exceeded)
+		reader = io.LimitReader(gz, maxSize+1)
+	}
+
+	content, err := readAllLimited(ctx, reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to decompress: %w", err)
+	}
+	if maxSize > 0 && int64(len(content)) > maxSize {
+		return "", fmt.Errorf("decompressed gzip: %w", errTooLarge)
+	}
+
+	switch sniffBytes(content[:min(len(content), sniffSize)]) {
+	case kindText:
+		return string(content), nil
+	case kindUTF16LE:
+		return decodeUTF16(content, false), nil
+	case kindUTF16BE:
+		return decodeUTF16(content, true), nil
+	}
+	return "", nil
+}
+
+// decodeUTF16 converts UTF-16 bytes (with or without BOM) to a string
+func decodeUTF16(data []byte, bigEndian bool) string {
+	if len(data) >= 2 && (data[0] == 0xFF && data[1] == 0xFE || data[0] == 0xFE && data[1] == 0xFF) {
+		data = data[2:]
+	}
+
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		if bigEndian {
+			units[i] = uint16(data[2*i])<<8 | uint16(data[2*i+1])
+		} else {
+			units[i] = uint16(data[2*i+1])<<8 | uint16(data[2*i])
+		}
+	}
+	return string(utf16.Decode(units))
+}
@@ -0,0 +1,144 @@
+// Package scanner - Per-file scan errors
+// File: internal/scanner/file_error.go
+//
+// Files that couldn't be read or parsed are recorded in
+// ScanResult.Errors instead of only being printed, so a report shows
+// exactly which files were NOT covered by the scan and why.
+package scanner
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+)
+
+// Scan stages a file error can happen in
+const (
+	StageWalk    = "walk"    // Listing directories / stat of the file
+	StageRead    = "read"    // Opening or reading the file
+	StageExtract = "extract" // Extracting text (PDF, office, gzip, SQLite, ...)
+)
+
+// ErrorClass groups file errors by cause
+type ErrorClass string
+
+const (
+	ErrorPermission ErrorClass = "permission" // Access denied
+	ErrorParse      ErrorClass = "parse"      // Corrupted, encrypted or unsupported content
+	ErrorTimeout    ErrorClass = "timeout"    // Reading/parsing took too long
+	ErrorTooLarge   ErrorClass = "too-large"  // Content exceeds the size limit or memory budget
+	ErrorIO         ErrorClass = "io"         // Other I/O errors (missing file, disk errors, ...)
+)
+
+// ErrorClasses lists every class in display order
+var ErrorClasses = []ErrorClass{ErrorPermission, ErrorParse, ErrorTimeout, ErrorTooLarge, ErrorIO}
+
+// errTooLarge is returned by readers that hit the size limit
+var errTooLarge = errors.New("content exceeds maximum file size")
+
+// FileError describes a file that could not be (fully) scanned
+type FileError struct {
+	Path  string     // File (or table/object) that failed
+	Stage string     // StageWalk, StageRead or StageExtract
+	Class ErrorClass // Cause category
+	Err   error      // Underlying error
+}
+
+// Error implements the error interface
+func (e *FileError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error (for errors.Is / errors.As)
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// newFileError wraps err with its path and stage and classifies it
+//
+// Parameters:
+//   - path: File that failed
+//   - stage: StageWalk, StageRead or StageExtract
+//   - err: Underlying error
+//
+// Returns:
+//   - *FileError: Classified error (returned as-is if err already is one)
+//
+// Example:
+//
+//	return nil, newFileError(filePath, StageExtract, fmt.Errorf("failed to read PDF: %w", err))
+func newFileError(path, stage string, err error) *FileError {
+	var fileErr *FileError
+	if errors.As(err, &fileErr) {
+		return fileErr
+	}
+
+	return &FileError{
+		Path:  path,
+		Stage: stage,
+		Class: classifyError(stage, err),
+		Err:   err,
+	}
+}
+
+// classifyError picks the ErrorClass for an error
+// Errors during text extraction that aren't I/O errors are parse errors
+func classifyError(stage string, err error) ErrorClass {
+	switch {
+	case errors.Is(err, fs.ErrPermission):
+		return ErrorPermission
+	case errors.Is(err, context.DeadlineExceeded), errors.Is(err, os.ErrDeadlineExceeded):
+		return ErrorTimeout
+	case errors.Is(err, errTooLarge), errors.Is(err, errMemoryBudget):
+		return ErrorTooLarge
+	}
+
+	var pathErr *fs.PathError
+	if stage == StageExtract && !errors.As(err, &pathErr) {
+		return ErrorParse
+	}
+	return ErrorIO
+}
+
+// addFileError records a failed file in the result
+// path is what the report shows (an s3:// URL rather than a temp file).
+// Nothing is printed: callers report the error to the progress callback.
+func (r *ScanResult) addFileError(path, stage string, err error) {
+	fileErr := *newFileError(path, stage, err)
+	fileErr.Path = path
+	r.Errors = append(r.Errors, fileErr)
+}
+
+// walkFailed records a path the walk couldn't list or stat and reports
+// it to the progress callback as a FileErrored event
+//
+// Parameters:
+//   - config: Scanner configuration (its ProgressCallback is called)
+//   - result: Scan result the error is added to
+//   - path: File, directory or root that failed
+//   - err: What went wrong
+func walkFailed(config *Config, result *ScanResult, path string, err error) {
+	result.addFileError(path, StageWalk, err)
+	fileErr := result.Errors[len(result.Errors)-1]
+	emitProgress(config.ProgressCallback, ProgressEvent{
+		Type:       FileErrored,
+		Path:       path,
+		Err:        &fileErr,
+		CardsFound: result.CardsFound,
+	})
+}
+
+// ErrorCounts returns the number of file errors per class
+//
+// Example:
+//
+//	counts := result.ErrorCounts()
+//	fmt.Println(counts[scanner.ErrorPermission]) // 3
+func (r *ScanResult) ErrorCounts() map[ErrorClass]int {
+	counts := make(map[ErrorClass]int)
+	for _, fileErr := range r.Errors {
+		counts[fileErr.Class]++
+	}
+	return counts
+}
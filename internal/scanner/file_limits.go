@@ -0,0 +1,156 @@
+// Package scanner - Per-file timeout and memory budget
+// File: internal/scanner/file_limits.go
+//
+// A single malformed file (a PDF with a runaway stream, a zip bomb
+// disguised as DOCX) must not stall or exhaust the whole scan. Every
+// file is scanned under a context carrying two limits:
+//
+//	Timeout   Config.FileTimeout: readers check the context between pages,
+//	          streams and zip entries; a reader stuck inside a single call
+//	          is abandoned once the deadline passes
+//	Memory    Config.MaxFileMemory: bytes read or decompressed for the file
+//	          are charged against a budget; readers stop when it runs out
+//
+// Files that hit either limit are recorded as "timeout" / "too-large"
+// errors in ScanResult.Errors, like any other file that wasn't covered.
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// errMemoryBudget is returned by readers that run out of memory budget
+var errMemoryBudget = errors.New("per-file memory budget exceeded")
+
+// memoryBudgetKey is the context key of the per-file memory budget
+type memoryBudgetKey struct{}
+
+// memoryBudget counts the bytes allocated for one file
+// Safe for concurrent use (an abandoned reader may still be charging)
+type memoryBudget struct {
+	limit int64
+	used  atomic.Int64
+}
+
+// withFileLimits derives the context a single file is scanned under
+//
+// Parameters:
+//   - ctx: Parent context (cancelling it aborts the file)
+//   - timeout: Per-file timeout (0 = no timeout)
+//   - maxMemory: Per-file memory budget in bytes (0 = no limit)
+//
+// Returns:
+//   - context.Context: Context for the readers
+//   - context.CancelFunc: Releases the context (always call it)
+//
+// Example:
+//
+//	ctx, cancel := withFileLimits(ctx, 30*time.Second, 256*1024*1024)
+//	defer cancel()
+func withFileLimits(ctx context.Context, timeout time.Duration, maxMemory int64) (context.Context, context.CancelFunc) {
+	if maxMemory > 0 {
+		ctx = context.WithValue(ctx, memoryBudgetKey{}, &memoryBudget{limit: maxMemory})
+	}
+	if timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// chargeMemory reserves n bytes of the file's memory budget
+// Also reports a cancelled or expired context, so readers only need
+// one check per allocation
+//
+// Returns:
+//   - error: ctx.Err(), errMemoryBudget (wrapped) or nil
+func chargeMemory(ctx context.Context, n int64) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	budget, ok := ctx.Value(memoryBudgetKey{}).(*memoryBudget)
+	if !ok {
+		return nil
+	}
+	if budget.used.Add(n) > budget.limit {
+		return fmt.Errorf("%w (limit %d bytes)", errMemoryBudget, budget.limit)
+	}
+	return nil
+}
+
+// readAllLimited is io.ReadAll under the file's limits
+// The context is checked and the budget charged for every chunk, so a
+// decompression bomb stops at the budget instead of at MaxStreamSize
+//
+// Parameters:
+//   - ctx: Context from withFileLimits
+//   - r: Reader to drain
+//
+// Returns:
+//   - []byte: Everything read
+//   - error: Read error, ctx.Err() or errMemoryBudget (wrapped)
+func readAllLimited(ctx context.Context, r io.Reader) ([]byte, error) {
+	var buf bytes.Buffer
+	chunk := make([]byte, ChunkSize)
+
+	for {
+		n, err := r.Read(chunk)
+		if n > 0 {
+			if err := chargeMemory(ctx, int64(n)); err != nil {
+				return nil, err
+			}
+			buf.Write(chunk[:n])
+		}
+		if err == io.EOF {
+			return buf.Bytes(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// readFileLimited is os.ReadFile under the file's limits
+// The whole file is charged up front, before it is allocated, and the
+// context is checked between chunks so a slow disk or pipe can't hold
+// the file past its deadline
+func readFileLimited(ctx context.Context, filePath string) ([]byte, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if err := chargeMemory(ctx, info.Size()); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(int(info.Size()))
+	chunk := make([]byte, ChunkSize)
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		n, err := file.Read(chunk)
+		buf.Write(chunk[:n])
+		if err == io.EOF {
+			return buf.Bytes(), nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
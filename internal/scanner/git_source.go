@@ -0,0 +1,511 @@
+// Package scanner - Git History Source
+// File: internal/scanner/git_source.go
+//
+// This file scans the complete history of a git repository
+// A card committed by mistake and deleted in the next commit is gone
+// from the working tree, but it still lives in every clone. A directory
+// scan skips .git, so only a history scan can find it.
+//
+// HOW IT WORKS:
+//  1. Run "git log --all --raw" to list every blob added or modified
+//     by every commit on every ref (oldest commit first)
+//  2. Keep the first commit that introduced each blob hash
+//     (the same blob in 100 commits is scanned once)
+//  3. Stream blob contents through one "git cat-file --batch" process
+//  4. Run the text through the detector
+//  5. Report findings with path, line, commit SHA, author and date
+//
+// REQUIREMENTS:
+//
+//	A local git binary (uses PATH unless GitBinary is set).
+//	Reading through git handles packfiles, deltas and loose objects
+//	for us, and always matches the repository format version.
+//
+// LIMITATIONS:
+//   - Binary blobs (NUL byte in the first 8000 bytes) are skipped and
+//     counted as content-type skips, office documents and PDFs are only
+//     scanned in the working tree
+//   - Symlinks and submodules are skipped (no file content)
+package scanner
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/keraattin/BasicPanScanner/internal/detector"
+	"github.com/keraattin/BasicPanScanner/internal/filter"
+)
+
+// ============================================================
+// CONFIGURATION
+// ============================================================
+
+// GitSourceConfig holds the settings for a git history scan
+type GitSourceConfig struct {
+	// RepoPath is the repository to scan (working tree or bare repository)
+	RepoPath string
+
+	// GitBinary is the git executable to run (default: "git" from PATH)
+	GitBinary string
+
+	// ExtFilter decides which paths are scanned (optional)
+	ExtFilter *filter.ExtensionFilter
+
+	// DirFilter skips paths under excluded directories (optional)
+	// e.g. "vendor" skips vendor/... in every commit
+	DirFilter *filter.DirectoryFilter
+
+	// PathFilter applies include/exclude rules (optional)
+	// Repository paths are matched as if the repo were at "/",
+	// so "/config/*.yml" means config/*.yml at the top of the repo
+	PathFilter *filter.PathFilter
+
+	// MaxFileSize skips larger blobs (in bytes, 0 = no limit)
+	MaxFileSize int64
+
+	// MaxFileMemory bounds the bytes held in memory for one blob
+	// (like Config.MaxFileMemory, 0 = no limit)
+	// Larger blobs are recorded as too-large file errors
+	MaxFileMemory int64
+
+	// Issuers identifies card issuers, usually a *detector.BINDatabase
+	// (nil = the global database)
+	Issuers detector.IssuerResolver
+
+	// ProgressCallback is called after each blob (optional)
+	// Events carry the blob's path as Path (see ProgressEvent)
+	ProgressCallback func(ProgressEvent)
+
+	// Sink receives each blob's findings as soon as it is scanned (optional)
+	// SinkOnly keeps them out of the ScanResult (see Config.SinkOnly)
+	Sink     FindingSink
+	SinkOnly bool
+
+	// RecordScannedPaths fills ScanResult.ScannedPaths (see Config.RecordScannedPaths)
+	RecordScannedPaths bool
+}
+
+// GitSource scans every blob in the history of a git repository
+type GitSource struct {
+	config *GitSourceConfig
+}
+
+// gitCommit holds the commit metadata attached to findings
+type gitCommit struct {
+	SHA    string
+	Author string // "Name <email>"
+	Date   time.Time
+}
+
+// gitBlob is a unique blob and the first commit that introduced it
+type gitBlob struct {
+	Hash   string
+	Path   string
+	Commit *gitCommit
+}
+
+// gitBinaryCheckSize is how many bytes git itself inspects
+// to decide whether a blob is binary
+const gitBinaryCheckSize = 8000
+
+// ============================================================
+// CONSTRUCTOR
+// ============================================================
+
+// NewGitSource creates a new git history source
+//
+// Parameters:
+//   - config: Repository and filter settings
+//
+// Returns:
+//   - *GitSource: Source ready to scan
+//   - error: Error if git is not installed or the path is not a repository
+//
+// Example:
+//
+//	source, err := scanner.NewGitSource(&scanner.GitSourceConfig{
+//	    RepoPath:    "/src/payments-api",
+//	    MaxFileSize: 52428800, // 50MB
+//	})
+//	result, err := source.Scan()
+func NewGitSource(config *GitSourceConfig) (*GitSource, error) {
+	if config.GitBinary == "" {
+		config.GitBinary = "git"
+	}
+
+	if _, err := exec.LookPath(config.GitBinary); err != nil {
+		return nil, fmt.Errorf("git history scan requires git: %w", err)
+	}
+
+	source := &GitSource{config: config}
+
+	// Fails with a clear message if RepoPath isn't a repository
+	if _, err := source.git(context.Background(), "rev-parse", "--git-dir").Output(); err != nil {
+		return nil, fmt.Errorf("not a git repository: %s", config.RepoPath)
+	}
+
+	return source, nil
+}
+
+// git builds a git command that runs inside the repository
+// The process is killed when ctx is cancelled
+func (s *GitSource) git(ctx context.Context, args ...string) *exec.Cmd {
+	return exec.CommandContext(ctx, s.config.GitBinary, append([]string{"-C", s.config.RepoPath}, args...)...)
+}
+
+// ============================================================
+// SCAN
+// ============================================================
+
+// Scan scans every unique blob reachable from any ref
+//
+// For history scans a blob plays the role of a file:
+//   - TotalFiles counts unique blobs, ScannedFiles those actually scanned
+//   - GroupedByFile is keyed by path (all versions of a path together)
+//   - Each finding carries the commit that introduced the blob
+//
+// Returns:
+//   - *ScanResult: Findings with path, line and commit information
+//   - error: Error if git fails to list or read objects
+func (s *GitSource) Scan() (*ScanResult, error) {
+	return s.ScanContext(context.Background())
+}
+
+// ScanContext scans like Scan, stopping as soon as ctx is cancelled
+//
+// The git processes are killed with ctx. Blobs already scanned keep
+// their findings.
+//
+// Parameters:
+//   - ctx: Cancel it (or let its deadline pass) to stop the scan
+//
+// Returns:
+//   - *ScanResult: Results; on cancellation, the partial results so far
+//     (nil if the history was still being listed)
+//   - error: Error if git fails to list or read objects, or ctx.Err()
+//     if the scan was stopped
+//
+// Example:
+//
+//	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+//	defer stop()
+//	result, err := source.ScanContext(ctx)
+func (s *GitSource) ScanContext(ctx context.Context) (*ScanResult, error) {
+	startTime := time.Now()
+
+	// ============================================================
+	// PHASE 1: List unique blobs with their first commit
+	// ============================================================
+
+	blobs, err := s.listBlobs(ctx)
+	if err != nil && ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list git history: %w", err)
+	}
+
+	result := &ScanResult{
+		Roots:         []string{s.config.RepoPath},
+		TotalFiles:    len(blobs),
+		Findings:      make([]Finding, 0),
+		GroupedByFile: make(map[string][]Finding),
+	}
+
+	// ============================================================
+	// PHASE 2: Read blobs through "git cat-file --batch"
+	// ============================================================
+
+	cmd := s.git(ctx, "cat-file", "--batch")
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start git cat-file: %w", err)
+	}
+	reader := bufio.NewReader(stdout)
+
+	for i, blob := range blobs {
+		if ctx.Err() != nil {
+			break
+		}
+
+		content, skipped, err := s.readBlob(stdin, reader, blob.Hash)
+		if err != nil && ctx.Err() != nil {
+			// git was killed by the cancellation
+			break
+		}
+		if err != nil && !errors.Is(err, errGitObjectMissing) && !errors.Is(err, errMemoryBudget) {
+			stdin.Close()
+			cmd.Process.Kill()
+			cmd.Wait()
+			return nil, fmt.Errorf("failed to read blob %s (%s): %w", blob.Hash, blob.Path, err)
+		}
+
+		event := ProgressEvent{
+			Type:      FileFinished,
+			Path:      blob.Path,
+			Processed: i + 1,
+			Total:     len(blobs),
+		}
+
+		switch {
+		case err != nil:
+			// Object missing (e.g. shallow or partial clone) or over the
+			// memory budget: the other blobs can still be read
+			result.addFileError(blob.Path, StageRead, fmt.Errorf("blob %s: %w", blob.Hash, err))
+			fileErr := result.Errors[len(result.Errors)-1]
+			event.Type = FileErrored
+			event.Err = &fileErr
+		case skipped:
+			result.SkippedBySize++
+			event.Type, event.Reason = FileSkipped, SkipReasonSize
+		case bytes.IndexByte(content[:min(len(content), gitBinaryCheckSize)], 0) >= 0:
+			// Binary blob - nothing readable to scan
+			result.SkippedByContent++
+			event.Type, event.Reason = FileSkipped, SkipReasonContent
+		default:
+			result.recordScanned(blob.Path, s.config.RecordScannedPaths)
+			findings := s.scanBlob(blob, string(content))
+			event.Findings = len(findings)
+			result.recordFindings(blob.Path, findings, s.config.Sink, s.config.SinkOnly)
+		}
+
+		event.CardsFound = result.CardsFound
+		emitProgress(s.config.ProgressCallback, event)
+	}
+
+	stdin.Close()
+	// A cancelled git exits with "signal: killed", which is expected
+	if err := cmd.Wait(); err != nil && ctx.Err() == nil {
+		return nil, fmt.Errorf("git cat-file failed: %w", err)
+	}
+
+	result.Duration = time.Since(startTime)
+	if result.Duration.Seconds() > 0 {
+		result.ScanRate = float64(result.ScannedFiles) / result.Duration.Seconds()
+	}
+
+	return result, ctx.Err()
+}
+
+// scanBlob runs the detector over one blob's content
+func (s *GitSource) scanBlob(blob gitBlob, content string) []Finding {
+	var findings []Finding
+
+	for _, cardLoc := range detector.DetectCardsInFile(content, s.config.Issuers) {
+		findings = append(findings, Finding{
+			FilePath:     blob.Path,
+			LineNumber:   cardLoc.LineNumber,
+			CardType:     cardLoc.CardType,
+			CardNumber:   cardLoc.CardNumber,
+			MaskedCard:   detector.MaskCardNumber(cardLoc.CardNumber),
+			Timestamp:    time.Now(),
+			CommitSHA:    blob.Commit.SHA,
+			CommitAuthor: blob.Commit.Author,
+			CommitDate:   blob.Commit.Date,
+		})
+	}
+
+	return findings
+}
+
+// errGitObjectMissing is returned by readBlob when git reports an object
+// as missing (shallow clones, partial clones, corrupted object stores)
+var errGitObjectMissing = errors.New("object missing from the repository")
+
+// readBlob requests one object from a running "git cat-file --batch"
+//
+// Batch output format:
+//
+//	<hash> blob <size>\n<content>\n
+//	<hash> missing\n
+//
+// Returns:
+//   - []byte: Blob content (nil if skipped)
+//   - bool: true if the blob is larger than MaxFileSize
+//   - error: errGitObjectMissing if the object isn't in the repository or
+//     errMemoryBudget if it is larger than MaxFileMemory (the batch can
+//     go on), other errors if git's output can't be read
+func (s *GitSource) readBlob(stdin io.Writer, reader *bufio.Reader, hash string) ([]byte, bool, error) {
+	if _, err := fmt.Fprintln(stdin, hash); err != nil {
+		return nil, false, err
+	}
+
+	header, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, false, err
+	}
+
+	fields := strings.Fields(header)
+	if len(fields) == 2 && fields[1] == "missing" {
+		return nil, false, errGitObjectMissing
+	}
+	if len(fields) != 3 {
+		return nil, false, fmt.Errorf("unexpected git cat-file output: %q", strings.TrimSpace(header))
+	}
+
+	size, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid object size %q", fields[2])
+	}
+
+	// Large blob: consume it (plus the trailing newline) without keeping it
+	if s.config.MaxFileSize > 0 && size > s.config.MaxFileSize {
+		_, err := io.CopyN(io.Discard, reader, size+1)
+		return nil, true, err
+	}
+	if s.config.MaxFileMemory > 0 && size > s.config.MaxFileMemory {
+		if _, err := io.CopyN(io.Discard, reader, size+1); err != nil {
+			return nil, false, err
+		}
+		return nil, false, fmt.Errorf("blob is %d bytes: %w (limit %d bytes)", size, errMemoryBudget, s.config.MaxFileMemory)
+	}
+
+	content := make([]byte, size+1)
+	if _, err := io.ReadFull(reader, content); err != nil {
+		return nil, false, err
+	}
+
+	return content[:size], false, nil
+}
+
+// ============================================================
+// HISTORY LISTING
+// ============================================================
+
+// listBlobs walks all commits and returns every unique blob to scan
+//
+// Runs (NUL-separated output so any path name is safe):
+//
+//	git log --all --reverse --root -m --no-renames --raw --no-abbrev -z
+//
+// Each commit is a header token followed by pairs of tokens:
+//
+//	":100644 100644 <old> <new> M" "<path>"
+//
+// Deleted files, symlinks and submodules are ignored. Since commits come
+// oldest first, the first time a hash is seen is the commit that
+// introduced it.
+func (s *GitSource) listBlobs(ctx context.Context) ([]gitBlob, error) {
+	cmd := s.git(ctx, "log", "--all", "--reverse", "--root", "-m", "--no-renames",
+		"--raw", "--no-abbrev", "-z", "--format=%x01%H%x00%an <%ae>%x00%aI")
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	// On a read error git may still be writing: stop it and reap it
+	// so the process doesn't outlive the scan
+	fail := func(err error) ([]gitBlob, error) {
+		cmd.Process.Kill()
+		cmd.Wait()
+		return nil, err
+	}
+
+	var blobs []gitBlob
+	seen := make(map[string]bool)
+	var commit *gitCommit
+
+	reader := bufio.NewReader(stdout)
+	next := func() (string, error) {
+		token, err := reader.ReadString(0)
+		return strings.TrimSuffix(token, "\x00"), err
+	}
+
+	for {
+		token, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fail(err)
+		}
+		token = strings.TrimLeft(token, "\n")
+
+		switch {
+		case strings.HasPrefix(token, "\x01"):
+			// Commit header: SHA, author, date
+			commit = &gitCommit{SHA: strings.TrimPrefix(token, "\x01")}
+			if commit.Author, err = next(); err != nil {
+				return fail(err)
+			}
+			date, err := next()
+			if err != nil {
+				return fail(err)
+			}
+			commit.Date, _ = time.Parse(time.RFC3339, strings.TrimSpace(date))
+
+		case strings.HasPrefix(token, ":"):
+			// Raw diff entry, followed by the path
+			filePath, err := next()
+			if err != nil {
+				return fail(err)
+			}
+
+			fields := strings.Fields(token)
+			if len(fields) < 5 || commit == nil {
+				continue
+			}
+			mode, hash, status := fields[1], fields[3], fields[4]
+
+			if status == "D" || !strings.HasPrefix(mode, "100") || seen[hash] {
+				continue
+			}
+			if !s.shouldScanPath(filePath) {
+				continue
+			}
+			seen[hash] = true
+
+			blobs = append(blobs, gitBlob{Hash: hash, Path: filePath, Commit: commit})
+		}
+	}
+
+	if err := cmd.Wait(); err != nil {
+		return nil, fmt.Errorf("git log failed: %v: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return blobs, nil
+}
+
+// shouldScanPath applies the extension and directory filters to a repo path
+func (s *GitSource) shouldScanPath(filePath string) bool {
+	if s.config.DirFilter != nil {
+		for dir := path.Dir(filePath); dir != "." && dir != "/"; dir = path.Dir(dir) {
+			if s.config.DirFilter.ShouldSkip(dir) {
+				return false
+			}
+		}
+	}
+
+	if !s.config.PathFilter.ShouldScan("/" + filePath) {
+		return false
+	}
+
+	if s.config.ExtFilter != nil && !s.config.ExtFilter.ShouldScan(filePath) {
+		return false
+	}
+
+	return true
+}
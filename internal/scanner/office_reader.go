@@ -42,6 +42,7 @@ package scanner
 
 import (
 	"archive/zip"
+	"context"
 	"encoding/xml"
 	"fmt"
 	"io"
@@ -153,6 +154,7 @@ func isOfficeDocument(filePath string) bool {
 //   - Total: 17 office document formats!
 //
 // Parameters:
+//   - ctx: Per-file context (timeout and memory budget)
 //   - filePath: Full path to the office document
 //
 // Returns:
@@ -162,11 +164,11 @@ func isOfficeDocument(filePath string) bool {
 // Example:
 //
 //	Extract text from various formats
-//	text, err := readOfficeDocument("report.docx")    // Word
-//	text, err := readOfficeDocument("data.xlsm")      // Excel with macros
-//	text, err := readOfficeDocument("slides.pptx")    // PowerPoint
-//	text, err := readOfficeDocument("document.odt")   // OpenDocument
-func readOfficeDocument(filePath string) (string, error) {
+//	text, err := readOfficeDocument(ctx, "report.docx")    // Word
+//	text, err := readOfficeDocument(ctx, "data.xlsm")      // Excel with macros
+//	text, err := readOfficeDocument(ctx, "slides.pptx")    // PowerPoint
+//	text, err := readOfficeDocument(ctx, "document.odt")   // OpenDocument
+func readOfficeDocument(ctx context.Context, filePath string) (string, error) {
 	// Get file extension to determine document type
 	ext := strings.ToLower(filepath.Ext(filePath))
 
@@ -178,7 +180,7 @@ func readOfficeDocument(filePath string) (string, error) {
 	// All Word formats use the same XML structure (word/document.xml)
 	// The difference is just additional files for macros/templates
 	case ".docx", ".docm", ".dotx", ".dotm":
-		return readDOCX(filePath)
+		return readDOCX(ctx, filePath)
 
 	// ============================================================
 	// MICROSOFT EXCEL FAMILY
@@ -186,7 +188,7 @@ func readOfficeDocument(filePath string) (string, error) {
 	// All Excel formats use the same XML structure
 	// (xl/sharedStrings.xml and xl/worksheets/*.xml)
 	case ".xlsx", ".xlsm", ".xltx", ".xltm":
-		return readXLSX(filePath)
+		return readXLSX(ctx, filePath)
 
 	// ============================================================
 	// MICROSOFT POWERPOINT FAMILY
@@ -194,28 +196,28 @@ func readOfficeDocument(filePath string) (string, error) {
 	// All PowerPoint formats use the same XML structure
 	// (ppt/slides/*.xml)
 	case ".pptx", ".pptm", ".potx", ".potm":
-		return readPPTX(filePath)
+		return readPPTX(ctx, filePath)
 
 	// ============================================================
 	// OPENDOCUMENT TEXT (LibreOffice/OpenOffice)
 	// ============================================================
 	// ODT uses content.xml for main content
 	case ".odt":
-		return readODT(filePath)
+		return readODT(ctx, filePath)
 
 	// ============================================================
 	// OPENDOCUMENT SPREADSHEET (LibreOffice/OpenOffice)
 	// ============================================================
 	// ODS uses content.xml with different XML structure
 	case ".ods":
-		return readODS(filePath)
+		return readODS(ctx, filePath)
 
 	// ============================================================
 	// OPENDOCUMENT PRESENTATION (LibreOffice/OpenOffice)
 	// ============================================================
 	// ODP uses content.xml with slides
 	case ".odp":
-		return readODP(filePath)
+		return readODP(ctx, filePath)
 
 	default:
 		// Unsupported file type
@@ -249,6 +251,7 @@ func readOfficeDocument(filePath string) (string, error) {
 //	❌ Embedded objects
 //
 // Parameters:
+//   - ctx: Per-file context (timeout and memory budget)
 //   - filePath: Full path to the .docx file
 //
 // Returns:
@@ -257,13 +260,13 @@ func readOfficeDocument(filePath string) (string, error) {
 //
 // Example:
 //
-//	text, err := readDOCX("/documents/contract.docx")
+//	text, err := readDOCX(ctx, "/documents/contract.docx")
 //	if err != nil {
 //	    log.Printf("Failed to read DOCX: %v", err)
 //	    return
 //	}
 //	fmt.Printf("Extracted %d characters\n", len(text))
-func readDOCX(filePath string) (string, error) {
+func readDOCX(ctx context.Context, filePath string) (string, error) {
 	// ============================================================
 	// STEP 1: Open DOCX file as ZIP archive
 	// ============================================================
@@ -294,7 +297,7 @@ func readDOCX(filePath string) (string, error) {
 			defer rc.Close()
 
 			// Read the entire XML content
-			xmlContent, err := io.ReadAll(rc)
+			xmlContent, err := readAllLimited(ctx, rc)
 			if err != nil {
 				return "", fmt.Errorf("failed to read document.xml: %w", err)
 			}
@@ -418,6 +421,7 @@ func extractTextFromWordXML(xmlContent string) string {
 //	❌ Hidden sheets or cells
 //
 // Parameters:
+//   - ctx: Per-file context (timeout and memory budget)
 //   - filePath: Full path to the .xlsx file
 //
 // Returns:
@@ -426,13 +430,13 @@ func extractTextFromWordXML(xmlContent string) string {
 //
 // Example:
 //
-//	text, err := readXLSX("/reports/financials.xlsx")
+//	text, err := readXLSX(ctx, "/reports/financials.xlsx")
 //	if err != nil {
 //	    log.Printf("Failed to read XLSX: %v", err)
 //	    return
 //	}
 //	fmt.Printf("Extracted %d characters\n", len(text))
-func readXLSX(filePath string) (string, error) {
+func readXLSX(ctx context.Context, filePath string) (string, error) {
 	// ============================================================
 	// STEP 1: Open XLSX file as ZIP archive
 	// ============================================================
@@ -459,7 +463,7 @@ func readXLSX(filePath string) (string, error) {
 			}
 			defer rc.Close()
 
-			xmlContent, err := io.ReadAll(rc)
+			xmlContent, err := readAllLimited(ctx, rc)
 			if err != nil {
 				continue // Skip if can't read
 			}
@@ -479,7 +483,7 @@ func readXLSX(filePath string) (string, error) {
 			}
 			defer rc.Close()
 
-			xmlContent, err := io.ReadAll(rc)
+			xmlContent, err := readAllLimited(ctx, rc)
 			if err != nil {
 				continue // Skip if can't read
 			}
@@ -635,12 +639,13 @@ func extractTextFromWorksheetXML(xmlContent string) string {
 //	❌ Slide formatting
 //
 // Parameters:
+//   - ctx: Per-file context (timeout and memory budget)
 //   - filePath: Full path to the .pptx file
 //
 // Returns:
 //   - string: All text content from all slides
 //   - error: Error if file can't be opened or parsed
-func readPPTX(filePath string) (string, error) {
+func readPPTX(ctx context.Context, filePath string) (string, error) {
 	// ============================================================
 	// STEP 1: Open PPTX file as ZIP archive
 	// ============================================================
@@ -665,7 +670,7 @@ func readPPTX(filePath string) (string, error) {
 			}
 			defer rc.Close()
 
-			xmlContent, err := io.ReadAll(rc)
+			xmlContent, err := readAllLimited(ctx, rc)
 			if err != nil {
 				continue // Skip if can't read
 			}
@@ -758,6 +763,7 @@ func extractTextFromSlideXML(xmlContent string) string {
 //	✅ Table content
 //
 // Parameters:
+//   - ctx: Per-file context (timeout and memory budget)
 //   - filePath: Full path to the .odt file
 //
 // Returns:
@@ -766,8 +772,8 @@ func extractTextFromSlideXML(xmlContent string) string {
 //
 // Example:
 //
-//	text, err := readODT("/documents/report.odt")
-func readODT(filePath string) (string, error) {
+//	text, err := readODT(ctx, "/documents/report.odt")
+func readODT(ctx context.Context, filePath string) (string, error) {
 	// Open ODT file as ZIP archive
 	zipReader, err := zip.OpenReader(filePath)
 	if err != nil {
@@ -788,7 +794,7 @@ func readODT(filePath string) (string, error) {
 			}
 			defer rc.Close()
 
-			xmlContent, err := io.ReadAll(rc)
+			xmlContent, err := readAllLimited(ctx, rc)
 			if err != nil {
 				return "", fmt.Errorf("failed to read content.xml: %w", err)
 			}
@@ -823,6 +829,7 @@ func readODT(filePath string) (string, error) {
 //	✅ Content from all sheets
 //
 // Parameters:
+//   - ctx: Per-file context (timeout and memory budget)
 //   - filePath: Full path to the .ods file
 //
 // Returns:
@@ -831,8 +838,8 @@ func readODT(filePath string) (string, error) {
 //
 // Example:
 //
-//	text, err := readODS("/reports/data.ods")
-func readODS(filePath string) (string, error) {
+//	text, err := readODS(ctx, "/reports/data.ods")
+func readODS(ctx context.Context, filePath string) (string, error) {
 	// Open ODS file as ZIP archive
 	zipReader, err := zip.OpenReader(filePath)
 	if err != nil {
@@ -851,7 +858,7 @@ func readODS(filePath string) (string, error) {
 			}
 			defer rc.Close()
 
-			xmlContent, err := io.ReadAll(rc)
+			xmlContent, err := readAllLimited(ctx, rc)
 			if err != nil {
 				return "", fmt.Errorf("failed to read content.xml: %w", err)
 			}
@@ -885,6 +892,7 @@ func readODS(filePath string) (string, error) {
 //	✅ Slide titles
 //
 // Parameters:
+//   - ctx: Per-file context (timeout and memory budget)
 //   - filePath: Full path to the .odp file
 //
 // Returns:
@@ -893,8 +901,8 @@ func readODS(filePath string) (string, error) {
 //
 // Example:
 //
-//	text, err := readODP("/presentations/slides.odp")
-func readODP(filePath string) (string, error) {
+//	text, err := readODP(ctx, "/presentations/slides.odp")
+func readODP(ctx context.Context, filePath string) (string, error) {
 	// Open ODP file as ZIP archive
 	zipReader, err := zip.OpenReader(filePath)
 	if err != nil {
@@ -913,7 +921,7 @@ func readODP(filePath string) (string, error) {
 			}
 			defer rc.Close()
 
-			xmlContent, err := io.ReadAll(rc)
+			xmlContent, err := readAllLimited(ctx, rc)
 			if err != nil {
 				return "", fmt.Errorf("failed to read content.xml: %w", err)
 			}
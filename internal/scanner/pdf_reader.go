@@ -46,11 +46,11 @@ import (
 	"bytes"
 	"compress/lzw"
 	"compress/zlib"
+	"context"
 	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"fmt"
-	"io"
 	"log"
 	"math"
 	"os"
@@ -113,6 +113,10 @@ type PDFReader struct {
 	password       string // For encrypted PDFs
 	debugMode      bool   // Enable detailed logging
 	maxMemoryUsage int64  // Maximum memory to use
+
+	// Per-file limits
+	ctx context.Context // Timeout and memory budget (see file_limits.go)
+	err error           // Limit that stopped the extraction, if any
 }
 
 // PDFObject represents a PDF object with its metadata
@@ -172,6 +176,29 @@ type PDFMetadata struct {
 //	}
 //	text, err := reader.ExtractText()
 func NewPDFReader(filePath string) (*PDFReader, error) {
+	return NewPDFReaderContext(context.Background(), filePath)
+}
+
+// NewPDFReaderContext creates a PDF reader bound to a context
+//
+// Extraction stops between pages and streams once ctx is done, and
+// decompressed streams are charged against the per-file memory budget
+// (if ctx carries one).
+//
+// Parameters:
+//   - ctx: Context that bounds the extraction
+//   - filePath: Path to the PDF file
+//
+// Returns:
+//   - *PDFReader: Initialized reader instance
+//   - error: Error if file doesn't exist, isn't a PDF or exceeds the budget
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+//	defer cancel()
+//	reader, err := NewPDFReaderContext(ctx, "document.pdf")
+func NewPDFReaderContext(ctx context.Context, filePath string) (*PDFReader, error) {
 	// Validate file exists
 	fileInfo, err := os.Stat(filePath)
 	if err != nil {
@@ -191,10 +218,11 @@ func NewPDFReader(filePath string) (*PDFReader, error) {
 		xrefTable:      make(map[int]*PDFObject),
 		textChunks:     make([]TextChunk, 0),
 		maxMemoryUsage: MaxPDFSize,
+		ctx:            ctx,
 	}
 
-	// Read file data
-	reader.data, err = os.ReadFile(filePath)
+	// Read file data (charged against the memory budget first)
+	reader.data, err = readFileLimited(ctx, filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
@@ -226,6 +254,9 @@ func NewPDFReader(filePath string) (*PDFReader, error) {
 //	    log.Printf("Warning: %v", err)
 //	}
 //	// Use text even if error (partial extraction)
+//
+// If the reader's context expires or the memory budget runs out, no
+// text is returned: a half-read PDF must be reported, not scanned.
 func (r *PDFReader) ExtractText() (string, error) {
 	// Step 1: Parse PDF structure
 	if err := r.parsePDFStructure(); err != nil {
@@ -244,7 +275,7 @@ func (r *PDFReader) ExtractText() (string, error) {
 	}
 
 	// Step 4: Fallback extraction methods
-	if r.extractedText.Len() < 100 {
+	if r.extractedText.Len() < 100 && !r.stopped() {
 		// Try direct stream extraction
 		r.extractFromAllStreams()
 
@@ -255,6 +286,10 @@ func (r *PDFReader) ExtractText() (string, error) {
 		r.extractReadableStrings()
 	}
 
+	if r.stopped() {
+		return "", r.err
+	}
+
 	// Step 5: Clean and order text
 	finalText := r.processExtractedText()
 
@@ -435,8 +470,9 @@ func (r *PDFReader) decompressFlate(data []byte) ([]byte, error) {
 	}
 	defer reader.Close()
 
-	decompressed, err := io.ReadAll(reader)
+	decompressed, err := readAllLimited(r.ctx, reader)
 	if err != nil {
+		r.checkLimit(err)
 		return nil, fmt.Errorf("failed to read decompressed data: %w", err)
 	}
 
@@ -452,8 +488,9 @@ func (r *PDFReader) decompressLZW(data []byte) ([]byte, error) {
 	reader := lzw.NewReader(bytes.NewReader(data), lzw.MSB, 8)
 	defer reader.Close()
 
-	decompressed, err := io.ReadAll(reader)
+	decompressed, err := readAllLimited(r.ctx, reader)
 	if err != nil {
+		r.checkLimit(err)
 		return nil, fmt.Errorf("LZW decompression failed: %w", err)
 	}
 
@@ -589,6 +626,9 @@ func (r *PDFReader) decodeRunLength(data []byte) ([]byte, error) {
 // extractFromPages extracts text from all page objects
 func (r *PDFReader) extractFromPages() {
 	for pageNum, page := range r.pages {
+		if r.stopped() {
+			return
+		}
 		r.extractFromPage(page, pageNum+1)
 	}
 
@@ -617,6 +657,10 @@ func (r *PDFReader) extractFromPage(page *PDFObject, pageNum int) {
 	contents := r.getPageContents(page)
 
 	for _, content := range contents {
+		if r.stopped() {
+			return
+		}
+
 		// Decompress if needed
 		if filter, ok := content.Dictionary["Filter"].(string); ok {
 			decompressed, err := r.decompressStream(content.Stream, filter)
@@ -864,6 +908,9 @@ func (r *PDFReader) extractFromAllStreams() {
 	matches := streamRegex.FindAllSubmatch(r.data, -1)
 
 	for _, match := range matches {
+		if r.stopped() {
+			return
+		}
 		if len(match) < 3 {
 			continue
 		}
@@ -900,6 +947,9 @@ func (r *PDFReader) extractFromAllStreams() {
 //
 // Another fallback method using pattern matching
 func (r *PDFReader) extractUsingPatterns() {
+	if r.stopped() {
+		return
+	}
 	data := string(r.data)
 
 	// Pattern for text in parentheses
@@ -919,6 +969,9 @@ func (r *PDFReader) extractUsingPatterns() {
 	}
 
 	// Pattern for hex strings
+	if r.stopped() {
+		return
+	}
 	hexRegex := regexp.MustCompile(`<([0-9A-Fa-f\s]+)>`)
 	hexMatches := hexRegex.FindAllStringSubmatch(data, -1)
 
@@ -941,7 +994,11 @@ func (r *PDFReader) extractUsingPatterns() {
 func (r *PDFReader) extractReadableStrings() {
 	var currentString []rune
 
-	for _, b := range r.data {
+	for i, b := range r.data {
+		// Check the limits once per chunk, not once per byte
+		if i%ChunkSize == 0 && r.stopped() {
+			return
+		}
 		ch := rune(b)
 
 		if unicode.IsPrint(ch) || ch == '\n' || ch == '\t' {
@@ -996,6 +1053,23 @@ func (r *PDFReader) extractTextFromData(data []byte) string {
 // HELPER METHODS
 // ============================================================================
 
+// stopped reports whether extraction must stop because the per-file
+// timeout expired or the memory budget ran out (the cause is kept in r.err)
+func (r *PDFReader) stopped() bool {
+	if r.err == nil {
+		r.err = r.ctx.Err()
+	}
+	return r.err != nil
+}
+
+// checkLimit keeps err as the reason to stop if it's a limit error
+// (other decompression errors only skip the stream)
+func (r *PDFReader) checkLimit(err error) {
+	if r.err == nil && (errors.Is(err, errMemoryBudget) || r.ctx.Err() != nil) {
+		r.err = err
+	}
+}
+
 // isReadableText checks if text contains readable content
 func (r *PDFReader) isReadableText(text string) bool {
 	if len(text) < MinTextLength {
@@ -1189,7 +1263,13 @@ func (r *PDFReader) GetVersion() string {
 //	}
 //	fmt.Println(text)
 func ReadPDF(filePath string) (string, error) {
-	reader, err := NewPDFReader(filePath)
+	return ReadPDFContext(context.Background(), filePath)
+}
+
+// ReadPDFContext reads a PDF like ReadPDF, bounded by ctx
+// (see NewPDFReaderContext)
+func ReadPDFContext(ctx context.Context, filePath string) (string, error) {
+	reader, err := NewPDFReaderContext(ctx, filePath)
 	if err != nil {
 		return "", err
 	}
@@ -1198,8 +1278,8 @@ func ReadPDF(filePath string) (string, error) {
 }
 
 // readPDF is the lowercase wrapper for backward compatibility with scanner.go
-func readPDF(filePath string) (string, error) {
-	return ReadPDF(filePath)
+func readPDF(ctx context.Context, filePath string) (string, error) {
+	return ReadPDFContext(ctx, filePath)
 }
 
 // IsPDFFile checks if a file is a PDF
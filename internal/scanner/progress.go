@@ -0,0 +1,88 @@
+// Package scanner - Progress events
+// File: internal/scanner/progress.go
+//
+// Config.ProgressCallback receives one ProgressEvent per step of a file's
+// life, so tools embedding the scanner can show more than a counter:
+//
+//	FileSkipped    the walk rejected the file (Reason says which filter)
+//	FileStarted    the file is about to be read
+//	FileFinished   the file was scanned (Findings cards in it)
+//	FileErrored    the file could not be (fully) scanned (Err says why)
+//
+// Sources (SQL, git, S3) send the same events for tables, blobs and objects.
+package scanner
+
+// ProgressEventType tells what happened to a file
+type ProgressEventType string
+
+const (
+	FileStarted  ProgressEventType = "started"  // Scan of the file begins
+	FileFinished ProgressEventType = "finished" // File scanned
+	FileSkipped  ProgressEventType = "skipped"  // File not selected by the filters
+	FileErrored  ProgressEventType = "errored"  // File could not be (fully) scanned
+)
+
+// Skip reasons reported in ProgressEvent.Reason
+// (the labels the reports use for the matching skip counters)
+const (
+	SkipReasonSize    = "size"
+	SkipReasonExt     = "extension"
+	SkipReasonPath    = "path rules"
+	SkipReasonContent = "content type"
+	SkipReasonAge     = "age"
+	SkipReasonOwner   = "owner"
+	SkipReasonMinSize = "min size"
+	SkipReasonIgnore  = "ignore files"
+	SkipReasonSymlink = "symlinks"
+	SkipReasonSpecial = "special files"
+	SkipReasonMount   = "mount points"
+)
+
+// ProgressEvent describes one step of scanning a file
+//
+// Example:
+//
+//	config.ProgressCallback = func(event scanner.ProgressEvent) {
+//	    switch event.Type {
+//	    case scanner.FileErrored:
+//	        log.Printf("%s: %v", event.Path, event.Err)
+//	    case scanner.FileFinished:
+//	        fmt.Printf("\r%d/%d files", event.Processed, event.Total)
+//	    }
+//	}
+type ProgressEvent struct {
+	Type     ProgressEventType
+	Path     string     // File (table, object or blob path) the event is about
+	Reason   string     // FileSkipped: SkipReason* constant
+	Findings int        // FileFinished / FileErrored: cards found in this file
+	Err      *FileError // FileErrored: what went wrong
+
+	// Running totals (always set for started, finished and errored events)
+	Processed  int // Files finished or errored so far
+	Total      int // Files selected for scanning
+	CardsFound int // Cards found so far
+}
+
+// emitProgress calls the progress callback if one is set
+func emitProgress(callback func(ProgressEvent), event ProgressEvent) {
+	if callback != nil {
+		callback(event)
+	}
+}
+
+// skipFile counts a skipped file and reports it to the progress callback
+//
+// Parameters:
+//   - config: Scanner configuration (its ProgressCallback is called)
+//   - counter: ScanResult skip counter to increment
+//   - path: Skipped file
+//   - reason: SkipReason* constant
+//
+// Example:
+//
+//	skipFile(s.config, &result.SkippedByExt, path, SkipReasonExt)
+//	return nil
+func skipFile(config *Config, counter *int, path, reason string) {
+	*counter++
+	emitProgress(config.ProgressCallback, ProgressEvent{Type: FileSkipped, Path: path, Reason: reason})
+}
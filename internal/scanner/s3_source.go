@@ -0,0 +1,703 @@
+// Package scanner - S3 Object Storage Source
+// File: internal/scanner/s3_source.go
+//
+// This file scans objects stored in S3-compatible object storage
+// (AWS S3, MinIO, Ceph RGW, Wasabi, ...). Data lakes and log archives
+// are often only in a bucket, never on a disk we could walk.
+//
+// HOW IT WORKS:
+//  1. List bucket/prefix with ListObjectsV2 (paged, 1000 keys per page)
+//  2. Apply the extension filter and size limit to each key
+//  3. Download objects with a pool of workers (GetObject)
+//  4. Text goes straight to the detector; other content (PDF, office,
+//     SQLite, gzip, ...) is written to a temp file and goes through ScanFile
+//  5. Report findings as s3://bucket/key with version ID and ETag
+//
+// AUTHENTICATION:
+//
+//	Requests are signed with AWS Signature Version 4 (standard library
+//	only - no AWS SDK). Without an access key, requests are sent
+//	unsigned, which works for public buckets.
+//
+// ADDRESSING:
+//   - No Endpoint: virtual-hosted style, https://<bucket>.s3.<region>.amazonaws.com
+//   - Endpoint set: path style, <endpoint>/<bucket>/<key> (MinIO and friends)
+package scanner
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/keraattin/BasicPanScanner/internal/detector"
+	"github.com/keraattin/BasicPanScanner/internal/filter"
+)
+
+// ============================================================
+// CONFIGURATION
+// ============================================================
+
+// S3SourceConfig holds the settings for an object storage scan
+type S3SourceConfig struct {
+	// Bucket and Prefix select the objects to scan
+	// An empty prefix scans the whole bucket
+	Bucket string
+	Prefix string
+
+	// Endpoint is the base URL of an S3-compatible server
+	// e.g. "http://localhost:9000" for a local MinIO
+	// Empty means AWS S3 in Region
+	Endpoint string
+
+	// Region used for signing (default: "us-east-1")
+	Region string
+
+	// Credentials (optional - empty AccessKeyID sends unsigned requests)
+	AccessKeyID     string
+	SecretAccessKey string
+	SessionToken    string
+
+	// ExtFilter decides which keys are scanned (optional)
+	ExtFilter *filter.ExtensionFilter
+
+	// PathFilter applies include/exclude rules to keys (optional)
+	// Keys are matched as "/key", so "/2025/**/*.csv" is anchored at the bucket root
+	PathFilter *filter.PathFilter
+
+	// MaxFileSize skips larger objects (in bytes, 0 = no limit)
+	MaxFileSize int64
+
+	// FileTimeout / MaxFileMemory bound the PDF, office and SQLite
+	// readers per object, like Config.FileTimeout / Config.MaxFileMemory
+	FileTimeout   time.Duration
+	MaxFileMemory int64
+
+	// Issuers identifies card issuers, usually a *detector.BINDatabase
+	// (nil = the global database)
+	Issuers detector.IssuerResolver
+
+	// Workers is the number of objects downloaded concurrently (minimum 1)
+	Workers int
+
+	// HTTPClient is used for all requests
+	// (default: a client with a DefaultS3Timeout per-request timeout)
+	HTTPClient *http.Client
+
+	// ProgressCallback is called for each object (optional)
+	// Events carry the s3:// URL as Path (see ProgressEvent)
+	ProgressCallback func(ProgressEvent)
+
+	// Sink receives each object's findings as soon as it is scanned (optional)
+	// SinkOnly keeps them out of the ScanResult (see Config.SinkOnly)
+	Sink     FindingSink
+	SinkOnly bool
+
+	// RecordScannedPaths fills ScanResult.ScannedPaths (see Config.RecordScannedPaths)
+	RecordScannedPaths bool
+}
+
+// S3Source scans objects in an S3-compatible bucket
+type S3Source struct {
+	config *S3SourceConfig
+}
+
+// s3Object is one entry of a ListObjectsV2 response
+type s3Object struct {
+	Key  string `xml:"Key"`
+	Size int64  `xml:"Size"`
+	ETag string `xml:"ETag"`
+}
+
+// s3ListResult is the ListObjectsV2 response body
+type s3ListResult struct {
+	Contents              []s3Object `xml:"Contents"`
+	IsTruncated           bool       `xml:"IsTruncated"`
+	NextContinuationToken string     `xml:"NextContinuationToken"`
+}
+
+// DefaultS3Timeout bounds each request of the default S3 client,
+// including the download of the object body
+const DefaultS3Timeout = 5 * time.Minute
+
+// s3Error is the error body returned by S3 for failed requests
+type s3Error struct {
+	Code    string `xml:"Code"`
+	Message string `xml:"Message"`
+}
+
+// ============================================================
+// CONSTRUCTOR
+// ============================================================
+
+// NewS3Source creates a new object storage source
+//
+// Parameters:
+//   - config: Bucket, endpoint, credentials and filter settings
+//
+// Returns:
+//   - *S3Source: Source ready to scan
+//   - error: Error if the bucket is missing or the endpoint is invalid
+//
+// Example:
+//
+//	source, err := scanner.NewS3Source(&scanner.S3SourceConfig{
+//	    Bucket:          "log-archive",
+//	    Prefix:          "2025/",
+//	    Endpoint:        "http://localhost:9000",
+//	    AccessKeyID:     "minioadmin",
+//	    SecretAccessKey: "minioadmin",
+//	    Workers:         8,
+//	})
+//	result, err := source.Scan()
+func NewS3Source(config *S3SourceConfig) (*S3Source, error) {
+	if config.Bucket == "" {
+		return nil, fmt.Errorf("S3 bucket is required")
+	}
+
+	if config.Region == "" {
+		config.Region = "us-east-1"
+	}
+
+	if config.Endpoint != "" {
+		endpoint, err := url.Parse(config.Endpoint)
+		if err != nil || endpoint.Scheme == "" || endpoint.Host == "" {
+			return nil, fmt.Errorf("invalid S3 endpoint %q (expected e.g. http://localhost:9000)", config.Endpoint)
+		}
+	}
+
+	if config.HTTPClient == nil {
+		// http.DefaultClient has no timeout: a stalled server would
+		// hang the scan forever
+		config.HTTPClient = &http.Client{Timeout: DefaultS3Timeout}
+	}
+
+	return &S3Source{config: config}, nil
+}
+
+// ParseS3URL splits an s3://bucket/prefix URL into bucket and prefix
+//
+// Example:
+//
+//	ParseS3URL("s3://log-archive/2025/01/") => "log-archive", "2025/01/"
+func ParseS3URL(s3URL string) (bucket, prefix string, err error) {
+	if !strings.HasPrefix(s3URL, "s3://") {
+		return "", "", fmt.Errorf("invalid S3 URL %q (expected s3://bucket/prefix)", s3URL)
+	}
+
+	bucket, prefix, _ = strings.Cut(strings.TrimPrefix(s3URL, "s3://"), "/")
+	if bucket == "" {
+		return "", "", fmt.Errorf("invalid S3 URL %q: missing bucket", s3URL)
+	}
+
+	return bucket, prefix, nil
+}
+
+// ============================================================
+// SCAN
+// ============================================================
+
+// Scan lists the bucket/prefix and scans every matching object
+//
+// For object storage scans an object plays the role of a file:
+//   - TotalFiles counts listed objects, skip counters work as usual
+//   - GroupedByFile is keyed by "s3://bucket/key"
+//   - Findings carry the object's version ID (if versioned) and ETag
+//
+// Objects that fail to download, or turn out larger than MaxFileSize /
+// MaxFileMemory, are recorded as file errors and skipped.
+//
+// Returns:
+//   - *ScanResult: Findings located by s3:// URL and line
+//   - error: Error if the bucket can't be listed
+func (s *S3Source) Scan() (*ScanResult, error) {
+	return s.ScanContext(context.Background())
+}
+
+// ScanContext scans like Scan, stopping as soon as ctx is cancelled
+//
+// Running requests (listing and downloads) are cancelled with ctx.
+// Objects already scanned keep their findings.
+//
+// Parameters:
+//   - ctx: Cancel it (or let its deadline pass) to stop the scan
+//
+// Returns:
+//   - *ScanResult: Results; on cancellation, the partial results so far
+//   - error: Error if the bucket can't be listed, or ctx.Err() if the
+//     scan was stopped (the partial result is returned with it)
+//
+// Example:
+//
+//	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+//	defer stop()
+//	result, err := source.ScanContext(ctx)
+func (s *S3Source) ScanContext(ctx context.Context) (*ScanResult, error) {
+	startTime := time.Now()
+
+	// ============================================================
+	// PHASE 1: List and filter objects
+	// ============================================================
+
+	objects, err := s.listObjects(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list s3://%s/%s: %w", s.config.Bucket, s.config.Prefix, err)
+	}
+
+	result := &ScanResult{
+		Roots:         []string{"s3://" + s.config.Bucket + "/" + s.config.Prefix},
+		Findings:      make([]Finding, 0),
+		GroupedByFile: make(map[string][]Finding),
+	}
+
+	var toScan []s3Object
+	for _, object := range objects {
+		// "Directory" placeholder objects created by consoles
+		if strings.HasSuffix(object.Key, "/") {
+			continue
+		}
+
+		result.TotalFiles++
+
+		if !s.config.PathFilter.ShouldScan("/" + object.Key) {
+			s.skipObject(&result.SkippedByPath, object.Key, SkipReasonPath)
+			continue
+		}
+
+		if s.config.ExtFilter != nil && !s.config.ExtFilter.ShouldScan(object.Key) {
+			s.skipObject(&result.SkippedByExt, object.Key, SkipReasonExt)
+			continue
+		}
+
+		if s.config.MaxFileSize > 0 && object.Size > s.config.MaxFileSize {
+			s.skipObject(&result.SkippedBySize, object.Key, SkipReasonSize)
+			continue
+		}
+
+		toScan = append(toScan, object)
+	}
+
+	// ============================================================
+	// PHASE 2: Download and scan with a pool of workers
+	// ============================================================
+
+	workers := s.config.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	jobs := make(chan s3Object)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	processed := 0
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for object := range jobs {
+				findings, err := s.scanObject(ctx, object)
+				if err != nil && ctx.Err() != nil {
+					// Aborted by the cancellation, not the object's fault
+					continue
+				}
+
+				mu.Lock()
+				processed++
+				event := ProgressEvent{
+					Type:      FileFinished,
+					Path:      s.objectURL(object.Key),
+					Findings:  len(findings),
+					Processed: processed,
+					Total:     len(toScan),
+				}
+				if err != nil {
+					result.addFileError(s.objectURL(object.Key), StageRead, err)
+					fileErr := result.Errors[len(result.Errors)-1]
+					event.Type = FileErrored
+					event.Err = &fileErr
+				} else {
+					result.recordScanned(s.objectURL(object.Key), s.config.RecordScannedPaths)
+					result.recordFindings(s.objectURL(object.Key), findings, s.config.Sink, s.config.SinkOnly)
+				}
+				event.CardsFound = result.CardsFound
+				emitProgress(s.config.ProgressCallback, event)
+				mu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for _, object := range toScan {
+		select {
+		case jobs <- object:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	result.Duration = time.Since(startTime)
+	if result.Duration.Seconds() > 0 {
+		result.ScanRate = float64(result.ScannedFiles) / result.Duration.Seconds()
+	}
+
+	return result, ctx.Err()
+}
+
+// objectURL returns the s3:// URL reported for a key
+func (s *S3Source) objectURL(key string) string {
+	return "s3://" + s.config.Bucket + "/" + key
+}
+
+// scanObject downloads one object and detects cards in it
+//
+// The download is bounded by the smaller of MaxFileSize and MaxFileMemory:
+// the listed size may be stale, so a larger body is an errTooLarge
+// rather than being read into memory.
+func (s *S3Source) scanObject(ctx context.Context, object s3Object) ([]Finding, error) {
+	resp, err := s.do(ctx, "GET", object.Key, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	limit := s.downloadLimit()
+	body := io.Reader(resp.Body)
+	if limit > 0 {
+		// One extra byte tells "exactly at the limit" from "over it"
+		body = io.LimitReader(resp.Body, limit+1)
+	}
+
+	content, err := io.ReadAll(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download object: %w", err)
+	}
+	if limit > 0 && int64(len(content)) > limit {
+		return nil, fmt.Errorf("object is larger than %d bytes: %w", limit, errTooLarge)
+	}
+
+	versionID := resp.Header.Get("x-amz-version-id")
+	if versionID == "null" {
+		// Unversioned bucket
+		versionID = ""
+	}
+	etag := strings.Trim(resp.Header.Get("ETag"), `"`)
+	if etag == "" {
+		etag = strings.Trim(object.ETag, `"`)
+	}
+
+	var findings []Finding
+
+	if needsFileReader(object.Key, content) {
+		// PDF, office and SQLite readers work on files
+		findings, err = scanContentAsFile(object.Key, content, &Config{
+			FileTimeout:   s.config.FileTimeout,
+			MaxFileMemory: s.config.MaxFileMemory,
+			Issuers:       s.config.Issuers,
+		})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		for _, cardLoc := range detector.DetectCardsInFile(string(content), s.config.Issuers) {
+			findings = append(findings, Finding{
+				LineNumber: cardLoc.LineNumber,
+				CardType:   cardLoc.CardType,
+				CardNumber: cardLoc.CardNumber,
+				MaskedCard: detector.MaskCardNumber(cardLoc.CardNumber),
+				Timestamp:  time.Now(),
+			})
+		}
+	}
+
+	for i := range findings {
+		findings[i].FilePath = s.objectURL(object.Key)
+		findings[i].ObjectVersion = versionID
+		findings[i].ETag = etag
+	}
+
+	return findings, nil
+}
+
+// downloadLimit returns the most bytes read per object (0 = no limit)
+func (s *S3Source) downloadLimit() int64 {
+	limit := s.config.MaxFileSize
+	if memory := s.config.MaxFileMemory; memory > 0 && (limit <= 0 || memory < limit) {
+		limit = memory
+	}
+	return limit
+}
+
+// needsFileReader reports whether content must go through a file-based
+// reader (PDF, office documents, SQLite, gzip, UTF-16) instead of plain text
+func needsFileReader(name string, content []byte) bool {
+	switch sniffBytes(content[:min(len(content), sniffSize)]) {
+	case kindText, kindBinary:
+		return isOfficeDocument(name)
+	}
+	return true
+}
+
+// skipObject counts a skipped object and reports it to the progress callback
+func (s *S3Source) skipObject(counter *int, key, reason string) {
+	*counter++
+	emitProgress(s.config.ProgressCallback, ProgressEvent{Type: FileSkipped, Path: s.objectURL(key), Reason: reason})
+}
+
+// scanContentAsFile writes content to a temporary file (keeping the
+// extension, which the readers rely on) and scans it with ScanFile
+// under the per-file limits of config
+func scanContentAsFile(name string, content []byte, config *Config) ([]Finding, error) {
+	tmp, err := os.CreateTemp("", "panscan-*"+strings.ToLower(path.Ext(name)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(content)
+	tmp.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to write temp file: %w", err)
+	}
+
+	s := &basicScanner{config: config}
+	return s.ScanFile(tmp.Name())
+}
+
+// ============================================================
+// S3 API
+// ============================================================
+
+// listObjects pages through ListObjectsV2 for the configured prefix
+func (s *S3Source) listObjects(ctx context.Context) ([]s3Object, error) {
+	var objects []s3Object
+	continuationToken := ""
+
+	for {
+		query := url.Values{}
+		query.Set("list-type", "2")
+		if s.config.Prefix != "" {
+			query.Set("prefix", s.config.Prefix)
+		}
+		if continuationToken != "" {
+			query.Set("continuation-token", continuationToken)
+		}
+
+		resp, err := s.do(ctx, "GET", "", query)
+		if err != nil {
+			return nil, err
+		}
+
+		var page s3ListResult
+		err = xml.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("invalid ListObjectsV2 response: %w", err)
+		}
+
+		objects = append(objects, page.Contents...)
+
+		if !page.IsTruncated || page.NextContinuationToken == "" {
+			break
+		}
+		continuationToken = page.NextContinuationToken
+	}
+
+	return objects, nil
+}
+
+// do sends a signed request for a key ("" = the bucket itself)
+// Non-2xx responses are turned into errors using S3's XML error body
+// The request is cancelled with ctx
+func (s *S3Source) do(ctx context.Context, method, key string, query url.Values) (*http.Response, error) {
+	var endpoint *url.URL
+	if s.config.Endpoint != "" {
+		// Path style: http://localhost:9000/bucket/key
+		endpoint, _ = url.Parse(strings.TrimSuffix(s.config.Endpoint, "/"))
+		endpoint.Path += "/" + s.config.Bucket
+	} else {
+		// Virtual-hosted style: https://bucket.s3.region.amazonaws.com/key
+		endpoint = &url.URL{
+			Scheme: "https",
+			Host:   fmt.Sprintf("%s.s3.%s.amazonaws.com", s.config.Bucket, s.config.Region),
+		}
+	}
+	endpoint.Path += "/" + key
+	endpoint.RawPath = s3EscapePath(endpoint.Path)
+	endpoint.RawQuery = s3CanonicalQuery(query)
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.config.AccessKeyID != "" {
+		s.sign(req, time.Now().UTC())
+	}
+
+	resp, err := s.config.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode/100 != 2 {
+		defer resp.Body.Close()
+		var s3Err s3Error
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+		var statusErr error
+		if xml.Unmarshal(body, &s3Err) == nil && s3Err.Code != "" {
+			statusErr = fmt.Errorf("%s: %s (HTTP %d)", s3Err.Code, s3Err.Message, resp.StatusCode)
+		} else {
+			statusErr = fmt.Errorf("HTTP %s", resp.Status)
+		}
+		if resp.StatusCode == http.StatusForbidden {
+			// Classified as a permission error in ScanResult.Errors
+			return nil, fmt.Errorf("%w: %w", statusErr, fs.ErrPermission)
+		}
+		return nil, statusErr
+	}
+
+	return resp, nil
+}
+
+// ============================================================
+// AWS SIGNATURE VERSION 4
+// ============================================================
+
+// sign adds AWS Signature Version 4 headers to a request
+//
+// Steps (see AWS "Signature Version 4 signing process"):
+//  1. Canonical request: method, path, query, headers, payload hash
+//  2. String to sign: algorithm, timestamp, scope, hash of (1)
+//  3. Signing key: HMAC chain over date, region, service, "aws4_request"
+//  4. Authorization header with the hex HMAC of (2)
+//
+// Only bodiless requests are sent, so the payload hash is the hash of "".
+func (s *S3Source) sign(req *http.Request, now time.Time) {
+	const service = "s3"
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(nil)
+
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+	if s.config.SessionToken != "" {
+		req.Header.Set("x-amz-security-token", s.config.SessionToken)
+	}
+
+	// Canonical headers: lowercase names, sorted, host included
+	headers := map[string]string{"host": req.URL.Host}
+	for name := range req.Header {
+		lower := strings.ToLower(name)
+		if lower == "host" || strings.HasPrefix(lower, "x-amz-") {
+			headers[lower] = strings.TrimSpace(req.Header.Get(name))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + s.config.Region + "/" + service + "/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+s.config.SecretAccessKey), date)
+	key = hmacSHA256(key, s.config.Region)
+	key = hmacSHA256(key, service)
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.config.AccessKeyID, scope, signedHeaders, signature))
+}
+
+// s3CanonicalQuery encodes query parameters sorted by name,
+// with RFC 3986 escaping (%20 for spaces, not "+") as SigV4 requires
+func s3CanonicalQuery(query url.Values) string {
+	names := make([]string, 0, len(query))
+	for name := range query {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var parts []string
+	for _, name := range names {
+		for _, value := range query[name] {
+			parts = append(parts, s3Escape(name, true)+"="+s3Escape(value, true))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// s3EscapePath escapes an object path, keeping "/" separators
+func s3EscapePath(p string) string {
+	return s3Escape(p, false)
+}
+
+// s3Escape percent-encodes everything except RFC 3986 unreserved
+// characters (A-Z a-z 0-9 - _ . ~) and, if encodeSlash is false, "/"
+func s3Escape(s string, encodeSlash bool) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z', 'a' <= c && c <= 'z', '0' <= c && c <= '9',
+			c == '-', c == '_', c == '.', c == '~':
+			b.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			b.WriteByte(c)
+		default:
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+// sha256Hex returns the lowercase hex SHA-256 of data
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// hmacSHA256 returns HMAC-SHA256(key, data)
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
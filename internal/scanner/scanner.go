@@ -12,14 +12,16 @@
 package scanner
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
+	"strconv"
 	"time"
 
-	"../detector"
-	"../filter"
+	"github.com/keraattin/BasicPanScanner/internal/detector"
+	"github.com/keraattin/BasicPanScanner/internal/filter"
 )
 
 // ============================================================
@@ -35,20 +37,86 @@ type Finding struct {
 	CardNumber string    // Full card number (digits only)
 	MaskedCard string    // PCI-compliant masked version
 	Timestamp  time.Time // When the finding was made
+
+	// Database location (SQLite files and SQL sources)
+	// Line numbers mean nothing inside a database, so these
+	// replace LineNumber when the finding comes from a table
+	Schema     string // Schema name (SQL sources only)
+	Table      string // Table name
+	Column     string // Column name
+	RowID      int64  // Row identifier (rowid, SQLite files; 0 if the rowid is itself a card number)
+	PrimaryKey string // Primary key values, e.g. "id=42" (SQL sources)
+
+	// Git history location (git sources only)
+	// FilePath is the path inside the repository at that commit
+	CommitSHA    string    // Commit that introduced the blob
+	CommitAuthor string    // Commit author, "Name <email>"
+	CommitDate   time.Time // Author date of the commit
+
+	// Object storage location (S3 sources only)
+	// FilePath is the s3://bucket/key URL
+	ObjectVersion string // Object version ID (versioned buckets)
+	ETag          string // Object ETag (content hash for simple uploads)
+}
+
+// Location returns a human-readable location of the finding
+// This is what exporters display next to each card
+//
+// Returns:
+//   - string: "Line 12" for text files,
+//     "users.card_number (rowid 5)" for SQLite files,
+//     "public.users.card_number [id=42]" for SQL sources,
+//     "Line 12 @ 3f9c2a1b7d04" for git history
+func (f Finding) Location() string {
+	if f.Schema != "" || f.PrimaryKey != "" {
+		location := f.Table + "." + f.Column
+		if f.Schema != "" {
+			location = f.Schema + "." + location
+		}
+		if f.PrimaryKey != "" {
+			location += " [" + f.PrimaryKey + "]"
+		}
+		return location
+	}
+	if f.Table != "" {
+		// WITHOUT ROWID tables have no rowid to show, and a rowid that
+		// is a card number is never shown
+		if f.RowID == 0 {
+			return fmt.Sprintf("%s.%s", f.Table, f.Column)
+		}
+		return fmt.Sprintf("%s.%s (rowid %d)", f.Table, f.Column, f.RowID)
+	}
+	if f.CommitSHA != "" {
+		return fmt.Sprintf("Line %d @ %.12s", f.LineNumber, f.CommitSHA)
+	}
+	return fmt.Sprintf("Line %d", f.LineNumber)
 }
 
 // ScanResult holds the results of a scanning operation
 // This is returned after scanning completes
 type ScanResult struct {
-	TotalFiles    int                  // Total files found
-	ScannedFiles  int                  // Files actually scanned
-	SkippedBySize int                  // Files skipped due to size
-	SkippedByExt  int                  // Files skipped by extension filter
-	CardsFound    int                  // Total credit cards found
-	Findings      []Finding            // All findings
-	GroupedByFile map[string][]Finding // Findings grouped by file
-	Duration      time.Duration        // How long the scan took
-	ScanRate      float64              // Files per second
+	Roots            []string             // Paths (or source) that were scanned
+	TotalFiles       int                  // Total files found
+	ScannedFiles     int                  // Files actually scanned
+	ScannedPaths     []string             // Paths of the scanned files, only with Config.RecordScannedPaths (a git path once per commit)
+	SkippedBySize    int                  // Files skipped due to size
+	SkippedByExt     int                  // Files skipped by extension filter
+	SkippedByPath    int                  // Files skipped by path include/exclude rules
+	SkippedByContent int                  // Files skipped as binary/unsupported by content sniffing
+	SkippedByAge     int                  // Files skipped by newer-than/older-than
+	SkippedByOwner   int                  // Files skipped by owner/group filter
+	SkippedByMinSize int                  // Files skipped as smaller than the minimum size
+	SkippedByIgnore  int                  // Files skipped by .gitignore-style ignore files
+	SkippedSymlinks  int                  // Directory symlinks not followed (or broken / looping links)
+	SkippedSpecial   int                  // FIFOs, sockets and devices
+	SkippedMounts    int                  // Mount points not crossed (one-filesystem mode)
+	CardsFound       int                  // Total credit cards found
+	RowsScanned      int64                // Database rows read (SQL sources only)
+	Findings         []Finding            // All findings
+	Errors           []FileError          // Files that could not be (fully) scanned
+	GroupedByFile    map[string][]Finding // Findings grouped by file
+	Duration         time.Duration        // How long the scan took
+	ScanRate         float64              // Files per second
 }
 
 // Scanner interface defines the contract for file/directory scanning
@@ -57,9 +125,21 @@ type Scanner interface {
 	// ScanFile scans a single file for credit cards
 	ScanFile(filePath string) ([]Finding, error)
 
+	// ScanFileContext scans a single file, giving up when ctx is done
+	ScanFileContext(ctx context.Context, filePath string) ([]Finding, error)
+
 	// ScanDirectory scans a directory recursively
 	ScanDirectory(dirPath string) (*ScanResult, error)
 
+	// ScanDirectoryContext scans a directory recursively until ctx is done
+	ScanDirectoryContext(ctx context.Context, dirPath string) (*ScanResult, error)
+
+	// ScanPaths scans several directories and/or files in one pass
+	ScanPaths(paths []string) (*ScanResult, error)
+
+	// ScanPathsContext scans several directories and/or files until ctx is done
+	ScanPathsContext(ctx context.Context, paths []string) (*ScanResult, error)
+
 	// GetConfig returns the scanner configuration
 	GetConfig() *Config
 }
@@ -73,19 +153,80 @@ type Config struct {
 	// Directory filter for determining which directories to skip
 	DirFilter *filter.DirectoryFilter
 
+	// Path filter with ordered glob/regex include/exclude rules (optional)
+	// Applied to directories (pruning) and files, on top of the other filters
+	PathFilter *filter.PathFilter
+
+	// Ignore filter honouring .gitignore-style files found in each
+	// walked directory (optional)
+	IgnoreFilter *filter.IgnoreFilter
+
+	// FollowSymlinks walks into symlinked directories
+	// (each directory and file is still visited once, so loops are safe)
+	// false: directory symlinks are skipped and counted
+	// Symlinked files are scanned either way
+	FollowSymlinks bool
+
+	// OneFileSystem keeps the walk on the filesystem of each root
+	// (like find -xdev): mount points below it are skipped and counted
+	OneFileSystem bool
+
 	// Maximum file size to scan (in bytes)
 	// Files larger than this will be skipped
 	// 0 means no limit
 	MaxFileSize int64
 
+	// Attribute filter for age, ownership and minimum size (optional)
+	AttrFilter *filter.AttributeFilter
+
+	// FileTimeout bounds the time spent reading and scanning one file
+	// Files that take longer are recorded as timeout errors
+	// 0 means no limit
+	FileTimeout time.Duration
+
+	// MaxFileMemory bounds the bytes read and decompressed for one file
+	// (PDF streams, zip entries, gzip data); files that need more are
+	// recorded as too-large errors
+	// 0 means no limit
+	MaxFileMemory int64
+
 	// Number of worker goroutines for concurrent scanning
 	// 1 means single-threaded, >1 means concurrent
 	Workers int
 
+	// TypeDetection decides how files are selected for scanning
+	//   "extension" (or ""): extension filter only (default)
+	//   "content": extension filter ignored, files are selected by
+	//              sniffed content (binaries and unsupported types skipped)
+	//   "both": extension filter first, then content sniffing
+	// The reader is always chosen by content, whatever the mode
+	TypeDetection string
+
 	// Callback function for progress updates (optional)
-	// Called after each file is scanned
-	// Parameters: scannedCount, totalCount, cardsFound
-	ProgressCallback func(int, int, int)
+	// Called when a file is skipped, started, finished or failed
+	// (see ProgressEvent); calls never overlap
+	ProgressCallback func(ProgressEvent)
+
+	// Callback function for findings (optional)
+	// Called for every card as soon as its file is scanned, so callers
+	// can stream findings instead of waiting for the ScanResult
+	FindingCallback func(Finding)
+
+	// Sink receives each file's findings as soon as it is scanned (optional)
+	Sink FindingSink
+
+	// SinkOnly keeps findings out of ScanResult.Findings/GroupedByFile
+	// (they only go to Sink and FindingCallback; CardsFound still counts them)
+	SinkOnly bool
+
+	// RecordScannedPaths keeps the path of every scanned file in
+	// ScanResult.ScannedPaths, for reports that list clean files (JUnit)
+	// Off by default: the list grows with every file of the scan
+	RecordScannedPaths bool
+
+	// Issuers identifies card issuers, usually a *detector.BINDatabase
+	// nil means the global database (detector.MatchIssuer)
+	Issuers detector.IssuerResolver
 }
 
 // basicScanner is the default scanner implementation
@@ -144,9 +285,9 @@ func (s *basicScanner) GetConfig() *Config {
 //   - 100% self-contained
 //
 // HOW IT WORKS:
-//  1. Check if file is an office document (17 supported formats)
-//  2. If YES: Extract text using our pure GO parser (ZIP+XML)
-//  3. If NO: Read directly with os.ReadFile (plain text)
+//  1. Sniff the file content (magic numbers) - the name doesn't matter
+//  2. Office document, PDF, gzip or UTF-16: extract text with its reader
+//  3. Otherwise: Read directly with os.ReadFile (plain text)
 //  4. Pass text to credit card detector
 //  5. Convert results to Finding format
 //
@@ -164,6 +305,12 @@ func (s *basicScanner) GetConfig() *Config {
 //	   • Spreadsheet: ODS
 //	   • Presentation: ODP
 //
+//	✅ SQLite databases (.sqlite, .sqlite3, .db):
+//	   • Every table, column and row is read from the file format
+//	   • Findings carry table, column and rowid instead of a line
+//
+//	✅ gzip-compressed text (.gz logs) and UTF-16 text
+//
 // NOT SUPPORTED (would need external libraries):
 //
 //	❌ Old Office formats (.doc, .xls, .ppt) - binary format
@@ -187,47 +334,132 @@ func (s *basicScanner) GetConfig() *Config {
 //	Scanning an Excel spreadsheet (ZIP+XML parsing)
 //	findings, err := scanner.ScanFile("/data/customers.xlsx")
 func (s *basicScanner) ScanFile(filePath string) ([]Finding, error) {
+	return s.ScanFileContext(context.Background(), filePath)
+}
+
+// ScanFileContext scans a single file like ScanFile, under the per-file
+// timeout and memory budget of the configuration
+//
+// Readers check the context between pages, streams and zip entries,
+// SQLite databases for every page and plain text between read chunks
+// and again before detection. Detection itself runs in one call: if it
+// outlasts the deadline it is left to finish in the background and the
+// file is reported as timed out, so one bad file never stalls the scan.
+//
+// Parameters:
+//   - ctx: Parent context (cancel it to abort the file)
+//   - filePath: Path to the file to scan
+//
+// Returns:
+//   - []Finding: List of all credit cards found in the file
+//   - error: *FileError; class "timeout" or "too-large" when a limit was hit
+//
+// Example:
+//
+//	config.FileTimeout = 30 * time.Second
+//	findings, err := scanner.ScanFileContext(ctx, "/documents/huge.pdf")
+func (s *basicScanner) ScanFileContext(ctx context.Context, filePath string) ([]Finding, error) {
+	ctx, cancel := withFileLimits(ctx, s.config.FileTimeout, s.config.MaxFileMemory)
+	defer cancel()
+
+	type scanOutcome struct {
+		findings []Finding
+		err      error
+	}
+
+	done := make(chan scanOutcome, 1) // Buffered: an abandoned scan must not block
+	go func() {
+		// A reader bug on a damaged file fails that file, not the scan
+		defer func() {
+			if r := recover(); r != nil {
+				done <- scanOutcome{nil, &FileError{
+					Path:  filePath,
+					Stage: StageExtract,
+					Class: ErrorParse,
+					Err:   fmt.Errorf("reader crashed on malformed content: %v", r),
+				}}
+			}
+		}()
+
+		findings, err := s.scanFile(ctx, filePath)
+		done <- scanOutcome{findings, err}
+	}()
+
+	select {
+	case outcome := <-done:
+		return outcome.findings, outcome.err
+	case <-ctx.Done():
+		if s.config.FileTimeout > 0 && ctx.Err() == context.DeadlineExceeded {
+			return nil, newFileError(filePath, StageExtract, fmt.Errorf("timed out after %s: %w", s.config.FileTimeout, ctx.Err()))
+		}
+		return nil, newFileError(filePath, StageExtract, fmt.Errorf("scan aborted: %w", ctx.Err()))
+	}
+}
+
+// scanFile does the work of ScanFileContext (see ScanFile for the steps)
+func (s *basicScanner) scanFile(ctx context.Context, filePath string) ([]Finding, error) {
 	// ============================================================
 	// STEP 1: Read file content
 	// ============================================================
 
 	var text string // Will hold the file content as text
-	var err error
+
+	// Identify the file by content (magic numbers), not by name
+	// A renamed "export.dat" that is really XLSX still goes to the XLSX reader
+	kind, err := sniffFile(filePath)
+	if err != nil {
+		return nil, newFileError(filePath, StageRead, fmt.Errorf("failed to read file: %w", err))
+	}
+
+	// Check if SQLite database
+	// Databases are walked cell by cell instead of being read as text,
+	// so they produce findings directly (with table/column/rowid)
+	if kind == kindSQLite {
+		return s.scanSQLiteFile(ctx, filePath)
+	}
 
 	// Check if PDF file
-	if isPDF, _ := isPDFFile(filePath); isPDF {
-		text, err = readPDF(filePath)
+	if kind == kindPDF {
+		text, err = readPDF(ctx, filePath)
 		if err != nil {
-			// Log the error but continue with empty text
-			log.Printf("Warning: PDF read error for %s: %v", filePath, err)
-			text = "" // Continue with empty text
+			// The file is NOT covered - report it instead of scanning empty text
+			return nil, newFileError(filePath, StageExtract, fmt.Errorf("failed to read PDF: %w", err))
 		}
 
-		// Check if this is an office document
-	} else if isOfficeDocument(filePath) {
-		// OFFICE DOCUMENT PATH
-		// This handles: .docx, .xlsx, .pptx
+		// Check if this is an office document, gzip or UTF-16 text
+	} else if kind != kindText && kind.scannable() {
+		// CONTENT-ROUTED PATH
+		// This handles: DOCX/XLSX/PPTX, ODT/ODS/ODP (whatever the name),
+		// gzip-compressed text and UTF-16 text
 		//
-		// How it works:
+		// Office documents work like this:
 		//  1. Open file as ZIP archive (archive/zip)
 		//  2. Extract XML files inside
 		//  3. Parse XML to get text (encoding/xml)
 		//  4. Return plain text
 		//
 		// All using GO standard library!
-		text, err = readOfficeDocument(filePath)
+		text, err = readByKind(ctx, filePath, kind, s.config.MaxFileSize)
+		if err != nil {
+			// Return error with helpful message
+			return nil, newFileError(filePath, StageExtract, fmt.Errorf("failed to read %s content: %w", kind, err))
+		}
+	} else if isOfficeDocument(filePath) {
+		// Office extension but not a valid office container
+		// (corrupted, encrypted, or legacy OLE2 renamed to .docx)
+		text, err = readOfficeDocument(ctx, filePath)
 		if err != nil {
 			// Return error with helpful message
-			return nil, fmt.Errorf("failed to read office document: %w", err)
+			return nil, newFileError(filePath, StageExtract, fmt.Errorf("failed to read office document: %w", err))
 		}
 	} else {
 		// PLAIN TEXT FILE PATH
 		// This handles: txt, log, csv, json, xml, html, code files, etc.
 		//
 		// Just read the file directly - it's already text!
-		content, err := os.ReadFile(filePath)
+		content, err := readFileLimited(ctx, filePath)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read file: %w", err)
+			return nil, newFileError(filePath, StageRead, fmt.Errorf("failed to read file: %w", err))
 		}
 		// Convert bytes to string
 		text = string(content)
@@ -244,7 +476,13 @@ func (s *basicScanner) ScanFile(filePath string) ([]Finding, error) {
 	//   Phase 2: Match issuer (BIN database lookup)
 	//   Phase 3: Validate Luhn (checksum)
 	//   Phase 4: Calculate line numbers
-	cardLocations := detector.DetectCardsInFile(text)
+	//
+	// Extraction may have used up the time: don't start detection on a
+	// file that has already timed out
+	if err := ctx.Err(); err != nil {
+		return nil, newFileError(filePath, StageExtract, err)
+	}
+	cardLocations := detector.DetectCardsInFile(text, s.config.Issuers)
 
 	// ============================================================
 	// STEP 3: Convert CardLocation to Finding
@@ -273,6 +511,67 @@ func (s *basicScanner) ScanFile(filePath string) ([]Finding, error) {
 	return findings, nil
 }
 
+// scanSQLiteFile scans every cell of a SQLite database for credit cards
+//
+// Each column value is run through the detector on its own, so a card
+// is reported with the table, column and rowid it was found in.
+//
+// Parameters:
+//   - ctx: Per-file context (checked for every page and cell)
+//   - filePath: Path to the database file
+//
+// Returns:
+//   - []Finding: Cards found, located by table/column/rowid
+//   - error: Error if the database can't be opened or read; returned
+//     together with the findings if reading stopped part way through
+func (s *basicScanner) scanSQLiteFile(ctx context.Context, filePath string) ([]Finding, error) {
+	reader, err := NewSQLiteReaderContext(ctx, filePath)
+	if err != nil {
+		return nil, newFileError(filePath, StageExtract, fmt.Errorf("failed to read SQLite database: %w", err))
+	}
+	defer reader.Close()
+
+	var findings []Finding
+
+	err = reader.WalkCells(func(cell SQLiteCell) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		for _, cardLoc := range detector.DetectCardsInFile(cell.Value, s.config.Issuers) {
+			// An INTEGER PRIMARY KEY column is the rowid: if it holds the
+			// card, the rowid is the unmasked PAN and must not be reported
+			if cell.RowID >= minCardRowID && len(detector.DetectCardsInFile(strconv.FormatInt(cell.RowID, 10), s.config.Issuers)) > 0 {
+				cell.RowID = 0
+			}
+
+			findings = append(findings, Finding{
+				FilePath:   filePath,
+				CardType:   cardLoc.CardType,
+				CardNumber: cardLoc.CardNumber,
+				MaskedCard: detector.MaskCardNumber(cardLoc.CardNumber),
+				Timestamp:  time.Now(),
+				Table:      cell.Table,
+				Column:     cell.Column,
+				RowID:      cell.RowID,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		// Keep what we found before the corrupted part
+		if len(findings) > 0 {
+			return findings, newFileError(filePath, StageExtract, fmt.Errorf("SQLite database partially read: %w", err))
+		}
+		return nil, newFileError(filePath, StageExtract, fmt.Errorf("failed to read SQLite database: %w", err))
+	}
+
+	return findings, nil
+}
+
+// minCardRowID is the smallest rowid long enough to be a card number
+// (13 digits); smaller rowids are reported without a check
+const minCardRowID = 1_000_000_000_000
+
 // ============================================================
 // SCAN DIRECTORY FUNCTION
 // ============================================================
@@ -298,13 +597,45 @@ func (s *basicScanner) ScanFile(filePath string) ([]Finding, error) {
 //	fmt.Printf("Found: %d cards\n", result.CardsFound)
 //	fmt.Printf("Duration: %s\n", result.Duration)
 func (s *basicScanner) ScanDirectory(dirPath string) (*ScanResult, error) {
+	return s.ScanDirectoryContext(context.Background(), dirPath)
+}
+
+// ScanDirectoryContext scans a directory like ScanDirectory, stopping
+// as soon as ctx is cancelled
+//
+// Findings are passed to Config.FindingCallback as they are discovered
+// and every file's progress to Config.ProgressCallback, so an embedding
+// tool can stream results and stop the scan early.
+//
+// Parameters:
+//   - ctx: Cancel it (or let its deadline pass) to stop the scan
+//   - dirPath: Path to the directory to scan
+//
+// Returns:
+//   - *ScanResult: Results; on cancellation, the partial results so far
+//   - error: Error if directory can't be accessed, or ctx.Err() if the
+//     scan was stopped (the partial result is returned with it)
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+//	defer cancel()
+//
+//	config.FindingCallback = func(f scanner.Finding) {
+//	    fmt.Println(f.FilePath, f.MaskedCard)
+//	}
+//	result, err := scanner.NewScanner(config).ScanDirectoryContext(ctx, "/srv")
+//	if errors.Is(err, context.DeadlineExceeded) {
+//	    fmt.Printf("Stopped early: %d files scanned\n", result.ScannedFiles)
+//	}
+func (s *basicScanner) ScanDirectoryContext(ctx context.Context, dirPath string) (*ScanResult, error) {
 	// If workers > 1, use concurrent scanning
 	if s.config.Workers > 1 {
-		return s.scanDirectoryConcurrent(dirPath)
+		return s.scanDirectoryConcurrent(ctx, dirPath)
 	}
 
 	// Otherwise use single-threaded scanning
-	return s.scanDirectorySingleThreaded(dirPath)
+	return s.scanDirectorySingleThreaded(ctx, dirPath)
 }
 
 // ============================================================
@@ -320,90 +651,303 @@ func (s *basicScanner) ScanDirectory(dirPath string) (*ScanResult, error) {
 //  3. Scan each file
 //  4. Collect results
 //  5. Generate statistics
-func (s *basicScanner) scanDirectorySingleThreaded(dirPath string) (*ScanResult, error) {
+func (s *basicScanner) scanDirectorySingleThreaded(ctx context.Context, dirPath string) (*ScanResult, error) {
 	startTime := time.Now()
 
 	// Initialize result structure
 	result := &ScanResult{
+		Roots:         []string{dirPath},
 		Findings:      make([]Finding, 0),
 		GroupedByFile: make(map[string][]Finding),
 	}
 
 	// Collect all files first
+	filesToScan, err := s.collectFiles(ctx, dirPath, result, make(map[string]bool))
+	if err == nil {
+		// Scan each file
+		err = s.scanFiles(ctx, filesToScan, result)
+	}
+
+	// A cancelled scan still returns what it found so far
+	if err != nil && ctx.Err() == nil {
+		return nil, err
+	}
+
+	// Calculate statistics
+	result.Duration = time.Since(startTime)
+	if result.Duration.Seconds() > 0 {
+		result.ScanRate = float64(result.ScannedFiles) / result.Duration.Seconds()
+	}
+
+	return result, ctx.Err()
+}
+
+// collectFiles walks one root (directory or single file) and returns
+// the files that pass the directory, extension and size filters
+//
+// Parameters:
+//   - ctx: Stops the walk when cancelled
+//   - root: Directory or file to walk
+//   - result: Receives TotalFiles and skip counters
+//   - seen: Files already collected from other roots (skipped, not recounted)
+//
+// Returns:
+//   - []string: Files to scan
+//   - error: Error if the root itself can't be accessed, or ctx.Err()
+func (s *basicScanner) collectFiles(ctx context.Context, root string, result *ScanResult, seen map[string]bool) ([]string, error) {
 	var filesToScan []string
 
-	err := filepath.Walk(dirPath, func(path string, info os.FileInfo, err error) error {
+	err := walkTree(root, s.config, result, func(path string, info os.FileInfo, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
 		if err != nil {
-			return nil // Skip files we can't access
+			if path == root {
+				return err // The root itself is missing or unreadable
+			}
+			// Skip files we can't access, but keep track of them
+			walkFailed(s.config, result, path, err)
+			return nil
 		}
 
 		// Skip directories
 		if info.IsDir() {
 			// Check if directory should be excluded
 			// ShouldSkip returns true if we should skip this directory
-			if s.config.DirFilter != nil && s.config.DirFilter.ShouldSkip(path) {
+			// (roots are always walked, even if their name is excluded)
+			if path != root && s.config.DirFilter != nil && s.config.DirFilter.ShouldSkip(path) {
+				return filepath.SkipDir
+			}
+			if path != root && s.config.PathFilter.ShouldSkipDir(path) {
+				return filepath.SkipDir
+			}
+			if s.ignored(root, path, true, result) {
 				return filepath.SkipDir
 			}
 			return nil
 		}
 
+		// Overlapping roots (e.g. /data and /data/exports) list files twice
+		if seen[path] {
+			return nil
+		}
+		seen[path] = true
+
 		// Count total files
 		result.TotalFiles++
 
+		// FIFOs, sockets and devices: nothing to read (a FIFO would block)
+		if !info.Mode().IsRegular() {
+			skipFile(s.config, &result.SkippedSpecial, path, SkipReasonSpecial)
+			return nil
+		}
+
+		// Check path rules
+		if !s.config.PathFilter.ShouldScan(path) {
+			skipFile(s.config, &result.SkippedByPath, path, SkipReasonPath)
+			return nil
+		}
+
+		// Check ignore files (.gitignore, .panscanignore, ...)
+		if s.ignored(root, path, false, result) {
+			skipFile(s.config, &result.SkippedByIgnore, path, SkipReasonIgnore)
+			return nil
+		}
+
 		// Check extension filter
-		if s.config.ExtFilter != nil && !s.config.ExtFilter.ShouldScan(path) {
-			result.SkippedByExt++
+		// (not used when files are selected purely by content)
+		if s.config.TypeDetection != "content" && s.config.ExtFilter != nil && !s.config.ExtFilter.ShouldScan(path) {
+			skipFile(s.config, &result.SkippedByExt, path, SkipReasonExt)
 			return nil
 		}
 
 		// Check file size
 		if s.config.MaxFileSize > 0 && info.Size() > s.config.MaxFileSize {
-			result.SkippedBySize++
+			skipFile(s.config, &result.SkippedBySize, path, SkipReasonSize)
+			return nil
+		}
+
+		// Check age, ownership and minimum size
+		switch s.config.AttrFilter.Check(info) {
+		case filter.SkipAge:
+			skipFile(s.config, &result.SkippedByAge, path, SkipReasonAge)
+			return nil
+		case filter.SkipOwner:
+			skipFile(s.config, &result.SkippedByOwner, path, SkipReasonOwner)
+			return nil
+		case filter.SkipMinSize:
+			skipFile(s.config, &result.SkippedByMinSize, path, SkipReasonMinSize)
 			return nil
 		}
 
+		// Check content type (magic numbers, binary heuristics)
+		if s.config.TypeDetection == "content" || s.config.TypeDetection == "both" {
+			if kind, err := sniffFile(path); err == nil && !kind.scannable() {
+				skipFile(s.config, &result.SkippedByContent, path, SkipReasonContent)
+				return nil
+			}
+		}
+
 		filesToScan = append(filesToScan, path)
 		return nil
 	})
 
-	if err != nil {
-		return nil, err
+	return filesToScan, err
+}
+
+// ignored checks the ignore files for a path and records the ignore
+// files that exist but can't be read (their rules are not applied)
+func (s *basicScanner) ignored(root, path string, isDir bool, result *ScanResult) bool {
+	skip := s.config.IgnoreFilter.ShouldIgnore(root, path, isDir)
+	for _, err := range s.config.IgnoreFilter.TakeErrors() {
+		walkFailed(s.config, result, err.Path, err)
 	}
+	return skip
+}
+
+// scanFiles scans a list of collected files and adds the findings to result
+// Files that fail to scan are recorded in result.Errors and skipped
+//
+// Returns:
+//   - error: ctx.Err() if the scan was cancelled before the last file
+func (s *basicScanner) scanFiles(ctx context.Context, filesToScan []string, result *ScanResult) error {
+	total := len(filesToScan)
 
-	// Scan each file
 	for i, filePath := range filesToScan {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		emitProgress(s.config.ProgressCallback, ProgressEvent{
+			Type:       FileStarted,
+			Path:       filePath,
+			Processed:  i,
+			Total:      total,
+			CardsFound: result.CardsFound,
+		})
+
 		// Scan the file
-		findings, err := s.ScanFile(filePath)
+		findings, err := s.ScanFileContext(ctx, filePath)
+		if err != nil && ctx.Err() != nil {
+			// Aborted by the cancellation, not the file's fault
+			return ctx.Err()
+		}
+
+		// Update statistics
+		// Partially read files (e.g. corrupted SQLite) keep what was found
+		if err == nil || len(findings) > 0 {
+			result.recordScanned(filePath, s.config.RecordScannedPaths)
+		}
+		// Store and stream findings
+		result.recordFindings(filePath, findings, s.config.Sink, s.config.SinkOnly)
+		if len(findings) > 0 {
+			if s.config.FindingCallback != nil {
+				for _, finding := range findings {
+					s.config.FindingCallback(finding)
+				}
+			}
+		}
+
+		event := ProgressEvent{
+			Type:       FileFinished,
+			Path:       filePath,
+			Findings:   len(findings),
+			Processed:  i + 1,
+			Total:      total,
+			CardsFound: result.CardsFound,
+		}
 		if err != nil {
-			// Log error but continue scanning
+			// Record the error but continue scanning
 			// For office documents, this might be because file is corrupted
-			fmt.Printf("Warning: failed to scan %s: %v\n", filePath, err)
-			continue
+			// ScanFileContext already returns a *FileError with the stage
+			// that failed (read, extract): keep it as it is
+			var fileErr *FileError
+			if !errors.As(err, &fileErr) {
+				fileErr = newFileError(filePath, StageExtract, err)
+			}
+			result.Errors = append(result.Errors, *fileErr)
+			recorded := *fileErr
+			event.Type = FileErrored
+			event.Err = &recorded
 		}
+		emitProgress(s.config.ProgressCallback, event)
+	}
 
-		// Update statistics
-		result.ScannedFiles++
-		result.CardsFound += len(findings)
+	return nil
+}
 
-		// Store findings
-		if len(findings) > 0 {
-			result.Findings = append(result.Findings, findings...)
-			result.GroupedByFile[filePath] = findings
+// ============================================================
+// SCAN MULTIPLE PATHS
+// ============================================================
+
+// ScanPaths scans several roots in one pass and returns combined results
+// Each root may be a directory (walked recursively) or a single file,
+// so this also scans explicit file lists (e.g. from -files-from)
+//
+// All roots go through the same filters as ScanDirectory. Files reached
+// from more than one root are scanned once. Roots that don't exist are
+// recorded as walk-stage FileErrors in Errors (and sent to the progress
+// callback as FileErrored events) and skipped, so one stale entry in an
+// inventory list doesn't abort the whole scan.
+//
+// Parameters:
+//   - paths: Directories and/or files to scan
+//
+// Returns:
+//   - *ScanResult: Combined results, with every accessible root listed in Roots
+//   - error: Error if none of the roots could be accessed
+//
+// Example:
+//
+//	result, err := scanner.ScanPaths([]string{"/mnt/share1", "/mnt/share2", "/tmp/export.csv"})
+func (s *basicScanner) ScanPaths(paths []string) (*ScanResult, error) {
+	return s.ScanPathsContext(context.Background(), paths)
+}
+
+// ScanPathsContext scans several roots like ScanPaths, stopping as soon
+// as ctx is cancelled (see ScanDirectoryContext for streaming and the
+// partial result returned on cancellation)
+func (s *basicScanner) ScanPathsContext(ctx context.Context, paths []string) (*ScanResult, error) {
+	startTime := time.Now()
+
+	result := &ScanResult{
+		Findings:      make([]Finding, 0),
+		GroupedByFile: make(map[string][]Finding),
+	}
+
+	// Collect files from every root
+	var filesToScan []string
+	seen := make(map[string]bool)
+
+	for _, root := range paths {
+		files, err := s.collectFiles(ctx, root, result, seen)
+		if ctx.Err() != nil {
+			break
 		}
+		if err != nil {
+			walkFailed(s.config, result, root, err)
+			continue
+		}
+		result.Roots = append(result.Roots, root)
+		filesToScan = append(filesToScan, files...)
+	}
 
-		// Progress callback
-		if s.config.ProgressCallback != nil {
-			s.config.ProgressCallback(i+1, len(filesToScan), result.CardsFound)
+	if ctx.Err() == nil {
+		if len(result.Roots) == 0 && len(paths) > 0 {
+			return nil, fmt.Errorf("none of the %d paths could be accessed", len(paths))
 		}
+
+		// Scan each file
+		s.scanFiles(ctx, filesToScan, result)
 	}
 
-	// Calculate statistics
 	result.Duration = time.Since(startTime)
 	if result.Duration.Seconds() > 0 {
 		result.ScanRate = float64(result.ScannedFiles) / result.Duration.Seconds()
 	}
 
-	return result, nil
+	// A cancelled scan still returns what it found so far
+	return result, ctx.Err()
 }
 
 // ============================================================
@@ -412,9 +956,9 @@ func (s *basicScanner) scanDirectorySingleThreaded(dirPath string) (*ScanResult,
 
 // scanDirectoryConcurrent scans directory with multiple workers
 // This is faster but uses more CPU/memory
-func (s *basicScanner) scanDirectoryConcurrent(dirPath string) (*ScanResult, error) {
+func (s *basicScanner) scanDirectoryConcurrent(ctx context.Context, dirPath string) (*ScanResult, error) {
 	// For now, just use single-threaded
 	// Concurrent implementation would require worker pools and channels
 	// We'll keep it simple for learning
-	return s.scanDirectorySingleThreaded(dirPath)
+	return s.scanDirectorySingleThreaded(ctx, dirPath)
 }
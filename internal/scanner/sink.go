@@ -0,0 +1,65 @@
+// Package scanner - Finding sinks
+// File: internal/scanner/sink.go
+//
+// A FindingSink receives each file's findings as soon as the file is
+// scanned, so results can be written out while the scan is still
+// running. With SinkOnly set the findings are not kept in the ScanResult
+// at all, which keeps memory flat on scans with millions of findings
+// (the counters are still kept).
+package scanner
+
+// FindingSink receives findings while a scan runs
+//
+// WriteFindings is called once for every scanned file (table, object
+// or blob) with cards, in the order files finish. Calls never overlap,
+// so implementations need no locking. A sink that can fail (a file
+// writer) should remember the first error and report it when it is
+// closed; the scan doesn't stop for it.
+//
+// Example:
+//
+//	type printSink struct{}
+//
+//	func (printSink) WriteFindings(path string, findings []scanner.Finding) {
+//	    fmt.Printf("%s: %d cards\n", path, len(findings))
+//	}
+//
+//	config.Sink = printSink{}
+type FindingSink interface {
+	WriteFindings(path string, findings []Finding)
+}
+
+// recordFindings adds a scanned file's findings to the result
+//
+// Parameters:
+//   - path: File (table, object or blob path) the findings belong to
+//   - findings: Cards found in it (may be empty)
+//   - sink: Receives the findings (nil = none)
+//   - sinkOnly: Only count the findings, don't keep them in the result
+func (r *ScanResult) recordFindings(path string, findings []Finding, sink FindingSink, sinkOnly bool) {
+	r.CardsFound += len(findings)
+	if len(findings) == 0 {
+		return
+	}
+
+	if !sinkOnly {
+		r.Findings = append(r.Findings, findings...)
+		// Appended: a git path can have findings in several commits
+		r.GroupedByFile[path] = append(r.GroupedByFile[path], findings...)
+	}
+	if sink != nil {
+		sink.WriteFindings(path, findings)
+	}
+}
+
+// recordScanned counts a scanned file and, if asked, remembers its path
+//
+// Parameters:
+//   - path: File (table, object or blob path) that was scanned
+//   - keepPath: Add the path to ScannedPaths (Config.RecordScannedPaths)
+func (r *ScanResult) recordScanned(path string, keepPath bool) {
+	r.ScannedFiles++
+	if keepPath {
+		r.ScannedPaths = append(r.ScannedPaths, path)
+	}
+}
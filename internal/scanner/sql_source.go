@@ -0,0 +1,899 @@
+// Package scanner - Live SQL Database Source
+// File: internal/scanner/sql_source.go
+//
+// This file scans live relational databases through database/sql
+// Card data in application databases is the main PCI risk, and a file
+// scan of the data directory can't see it (pages, compression, TOAST...)
+//
+// HOW IT WORKS:
+//  1. Connect using a database/sql driver name and DSN
+//  2. Enumerate schemas, tables and columns (dialect-specific catalog query)
+//  3. Keep text-like columns (and numeric columns wide enough for a PAN)
+//  4. SELECT those columns plus the primary key, optionally with a row limit
+//  5. Run every value through the detector
+//  6. Report findings as schema.table.column + primary key
+//
+// SUPPORTED DIALECTS:
+//
+//	✅ SQLite      (drivers: sqlite, sqlite3)
+//	✅ PostgreSQL  (drivers: postgres, pgx)
+//	✅ MySQL       (drivers: mysql)
+//	✅ SQL Server  (drivers: sqlserver, mssql)
+//
+// DRIVERS:
+//
+//	This package only uses database/sql - it does NOT import any driver.
+//	The binary (or the program embedding the scanner) must register one
+//	with a blank import, e.g. import _ "github.com/lib/pq"
+package scanner
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/keraattin/BasicPanScanner/internal/detector"
+)
+
+// ============================================================
+// CONFIGURATION
+// ============================================================
+
+// SQLSourceConfig holds the settings for a database scan
+type SQLSourceConfig struct {
+	// Driver is the database/sql driver name (e.g., "postgres", "sqlite3")
+	// It also selects the SQL dialect used for catalog queries
+	Driver string
+
+	// DSN is the driver-specific data source name (connection string)
+	DSN string
+
+	// DB is an already-open connection (optional)
+	// When set, Driver is only used to pick the dialect and DSN only
+	// labels the result (Roots)
+	DB *sql.DB
+
+	// Schemas limits the scan to these schemas (empty = all user schemas)
+	Schemas []string
+
+	// Tables limits the scan to these tables (empty = all tables)
+	// Names may be plain ("users") or qualified ("public.users")
+	Tables []string
+
+	// RowLimit is the maximum number of rows read per table
+	// 0 means scan every row (full scan), >0 means sample the first N rows
+	RowLimit int
+
+	// Workers is the number of tables scanned concurrently (minimum 1)
+	Workers int
+
+	// Issuers identifies card issuers, usually a *detector.BINDatabase
+	// (nil = the global database)
+	Issuers detector.IssuerResolver
+
+	// ProgressCallback is called after each table (optional)
+	// Events carry the qualified table name as Path (see ProgressEvent)
+	ProgressCallback func(ProgressEvent)
+
+	// Sink receives each table's findings as soon as it is scanned (optional)
+	// SinkOnly keeps them out of the ScanResult (see Config.SinkOnly)
+	Sink     FindingSink
+	SinkOnly bool
+
+	// RecordScannedPaths fills ScanResult.ScannedPaths (see Config.RecordScannedPaths)
+	RecordScannedPaths bool
+}
+
+// SQLSource scans a live database for credit card numbers
+type SQLSource struct {
+	config  *SQLSourceConfig
+	dialect sqlDialect
+}
+
+// sqlColumn describes a column found in the database catalog
+type sqlColumn struct {
+	Name     string
+	DataType string
+}
+
+// sqlTable describes a table with its scannable and primary key columns
+type sqlTable struct {
+	Schema     string
+	Name       string
+	Columns    []sqlColumn // Columns to scan
+	PrimaryKey []string    // Primary key columns (may be empty)
+}
+
+// qualifiedName returns "schema.table" (just "table" if there's no schema)
+func (t sqlTable) qualifiedName() string {
+	if t.Schema == "" {
+		return t.Name
+	}
+	return t.Schema + "." + t.Name
+}
+
+// ============================================================
+// CONSTRUCTOR
+// ============================================================
+
+// NewSQLSource creates a new database source
+//
+// Parameters:
+//   - config: Connection and scan settings
+//
+// Returns:
+//   - *SQLSource: Source ready to scan
+//   - error: Error if the driver's dialect is not supported
+//
+// Example:
+//
+//	source, err := scanner.NewSQLSource(&scanner.SQLSourceConfig{
+//	    Driver:   "postgres",
+//	    DSN:      "postgres://audit:secret@db:5432/shop?sslmode=disable",
+//	    RowLimit: 10000,
+//	    Workers:  4,
+//	})
+//	result, err := source.Scan()
+func NewSQLSource(config *SQLSourceConfig) (*SQLSource, error) {
+	dialect, err := dialectForDriver(config.Driver)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SQLSource{
+		config:  config,
+		dialect: dialect,
+	}, nil
+}
+
+// ============================================================
+// SCAN
+// ============================================================
+
+// Scan scans every selected table and returns the combined results
+//
+// For database scans a table plays the role of a file:
+//   - TotalFiles / ScannedFiles count tables
+//   - GroupedByFile is keyed by "schema.table"
+//   - RowsScanned counts rows read across all tables
+//
+// Tables that fail to scan (permissions, unsupported types) are reported
+// as file errors and skipped, like unreadable files in ScanDirectory.
+//
+// Returns:
+//   - *ScanResult: Findings located by schema.table.column and primary key
+//   - error: Error if the connection or catalog query fails
+func (s *SQLSource) Scan() (*ScanResult, error) {
+	return s.ScanContext(context.Background())
+}
+
+// ScanContext scans like Scan, stopping as soon as ctx is cancelled
+//
+// Running queries are cancelled with ctx (QueryContext). A table that
+// fails part-way keeps the findings of the rows read before the error
+// and is also recorded as a file error.
+//
+// Parameters:
+//   - ctx: Cancel it (or let its deadline pass) to stop the scan
+//
+// Returns:
+//   - *ScanResult: Results; on cancellation, the partial results so far
+//   - error: Error if the connection or catalog query fails, or ctx.Err()
+//     if the scan was stopped (the partial result is returned with it)
+//
+// Example:
+//
+//	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+//	defer stop()
+//	result, err := source.ScanContext(ctx)
+func (s *SQLSource) ScanContext(ctx context.Context) (*ScanResult, error) {
+	startTime := time.Now()
+
+	db := s.config.DB
+	if db == nil {
+		var err error
+		db, err = sql.Open(s.config.Driver, s.config.DSN)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open database (registered drivers: %s): %w",
+				strings.Join(sql.Drivers(), ", "), err)
+		}
+		defer db.Close()
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
+	// ============================================================
+	// PHASE 1: Enumerate tables and columns
+	// ============================================================
+
+	tables, err := s.listTables(ctx, db)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tables: %w", err)
+	}
+
+	result := &ScanResult{
+		Roots:         []string{RedactDSN(s.config.DSN)},
+		TotalFiles:    len(tables),
+		Findings:      make([]Finding, 0),
+		GroupedByFile: make(map[string][]Finding),
+	}
+
+	// ============================================================
+	// PHASE 2: Scan tables with a pool of workers
+	// ============================================================
+
+	workers := s.config.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	jobs := make(chan sqlTable)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for table := range jobs {
+				findings, rows, err := s.scanTable(ctx, db, table)
+				// Aborted by the cancellation, not the table's fault
+				aborted := err != nil && ctx.Err() != nil
+
+				mu.Lock()
+				event := ProgressEvent{
+					Type:     FileFinished,
+					Path:     table.qualifiedName(),
+					Findings: len(findings),
+					Total:    result.TotalFiles,
+				}
+
+				// Failed tables are recorded, not counted as scanned
+				// (tables that failed part-way are both)
+				if err != nil && !aborted {
+					result.addFileError(table.qualifiedName(), StageRead, err)
+					fileErr := result.Errors[len(result.Errors)-1]
+					event.Type = FileErrored
+					event.Err = &fileErr
+				}
+				if err == nil || len(findings) > 0 {
+					result.recordScanned(table.qualifiedName(), s.config.RecordScannedPaths)
+				}
+				result.RowsScanned += rows
+				result.recordFindings(table.qualifiedName(), findings, s.config.Sink, s.config.SinkOnly)
+				event.Processed = result.ScannedFiles + len(result.Errors)
+				event.CardsFound = result.CardsFound
+				emitProgress(s.config.ProgressCallback, event)
+				mu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for _, table := range tables {
+		select {
+		case jobs <- table:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	result.Duration = time.Since(startTime)
+	if result.Duration.Seconds() > 0 {
+		result.ScanRate = float64(result.ScannedFiles) / result.Duration.Seconds()
+	}
+
+	return result, ctx.Err()
+}
+
+// scanTable reads the selected columns of one table and detects cards
+//
+// Returns:
+//   - []Finding: Cards found in this table (on error, in the rows read
+//     before it)
+//   - int64: Number of rows read
+//   - error: Error if the query fails or is cancelled
+func (s *SQLSource) scanTable(ctx context.Context, db *sql.DB, table sqlTable) ([]Finding, int64, error) {
+	// SELECT primary key columns first, then the columns to scan
+	selectCols := append(append([]string{}, table.PrimaryKey...), columnNames(table.Columns)...)
+	query := s.dialect.selectQuery(table, selectCols, s.config.RowLimit)
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	values := make([]sql.NullString, len(selectCols))
+	scanArgs := make([]interface{}, len(values))
+	for i := range values {
+		scanArgs[i] = &values[i]
+	}
+
+	var findings []Finding
+	var rowCount int64
+	pkCount := len(table.PrimaryKey)
+
+	for rows.Next() {
+		if err := rows.Scan(scanArgs...); err != nil {
+			return findings, rowCount, err
+		}
+		rowCount++
+
+		// Build "id=42" / "tenant=1,id=7" once per row, only if needed
+		primaryKey := ""
+
+		for i, col := range table.Columns {
+			value := values[pkCount+i]
+			if !value.Valid || value.String == "" {
+				continue
+			}
+
+			for _, cardLoc := range detector.DetectCardsInFile(value.String, s.config.Issuers) {
+				if primaryKey == "" {
+					primaryKey = formatPrimaryKey(table.PrimaryKey, values[:pkCount], s.config.Issuers)
+				}
+
+				findings = append(findings, Finding{
+					FilePath:   table.qualifiedName(),
+					CardType:   cardLoc.CardType,
+					CardNumber: cardLoc.CardNumber,
+					MaskedCard: detector.MaskCardNumber(cardLoc.CardNumber),
+					Timestamp:  time.Now(),
+					Schema:     table.Schema,
+					Table:      table.Name,
+					Column:     col.Name,
+					PrimaryKey: primaryKey,
+				})
+			}
+		}
+	}
+
+	return findings, rowCount, rows.Err()
+}
+
+// listTables queries the catalog and applies schema/table/column filters
+func (s *SQLSource) listTables(ctx context.Context, db *sql.DB) ([]sqlTable, error) {
+	tables, err := s.dialect.listTables(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+
+	wantSchema := make(map[string]bool)
+	for _, schema := range s.config.Schemas {
+		wantSchema[strings.TrimSpace(schema)] = true
+	}
+	wantTable := make(map[string]bool)
+	for _, name := range s.config.Tables {
+		wantTable[strings.TrimSpace(name)] = true
+	}
+
+	var selected []sqlTable
+	for _, table := range tables {
+		if len(wantSchema) > 0 && !wantSchema[table.Schema] {
+			continue
+		}
+		if len(wantTable) > 0 && !wantTable[table.Name] && !wantTable[table.qualifiedName()] {
+			continue
+		}
+
+		// Keep only columns that can hold a PAN
+		var columns []sqlColumn
+		for _, col := range table.Columns {
+			if s.dialect.scannable(col.DataType) {
+				columns = append(columns, col)
+			}
+		}
+		if len(columns) == 0 {
+			continue
+		}
+		table.Columns = columns
+		selected = append(selected, table)
+	}
+
+	// Stable order makes reports and progress reproducible
+	sort.Slice(selected, func(i, j int) bool {
+		return selected[i].qualifiedName() < selected[j].qualifiedName()
+	})
+
+	return selected, nil
+}
+
+// ============================================================
+// SQL DIALECTS
+// ============================================================
+
+// sqlDialect knows how to list tables and build SELECTs for one database family
+type sqlDialect interface {
+	listTables(ctx context.Context, db *sql.DB) ([]sqlTable, error)
+	selectQuery(table sqlTable, columns []string, limit int) string
+	scannable(dataType string) bool
+}
+
+// dialectForDriver picks the SQL dialect from the database/sql driver name
+func dialectForDriver(driver string) (sqlDialect, error) {
+	switch strings.ToLower(driver) {
+	case "sqlite", "sqlite3":
+		return sqliteDialect{}, nil
+	case "postgres", "postgresql", "pgx":
+		return informationSchemaDialect{quote: `"`, quoteEnd: `"`, excluded: []string{"pg_catalog", "information_schema"}}, nil
+	case "mysql":
+		return informationSchemaDialect{quote: "`", quoteEnd: "`", excluded: []string{"mysql", "information_schema", "performance_schema", "sys"}}, nil
+	case "sqlserver", "mssql":
+		return informationSchemaDialect{quote: "[", quoteEnd: "]", top: true, excluded: []string{"INFORMATION_SCHEMA", "sys"}}, nil
+	default:
+		return nil, fmt.Errorf("unsupported database driver '%s' (use sqlite3, postgres, mysql or sqlserver)", driver)
+	}
+}
+
+// sqliteDialect lists tables with sqlite_master and PRAGMA table_info
+type sqliteDialect struct{}
+
+func (sqliteDialect) listTables(ctx context.Context, db *sql.DB) ([]sqlTable, error) {
+	rows, err := db.QueryContext(ctx, `SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'`)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var tables []sqlTable
+	for _, name := range names {
+		// PRAGMA table_info columns: cid, name, type, notnull, dflt_value, pk
+		info, err := db.QueryContext(ctx, `PRAGMA table_info(`+quoteIdent(name, `"`, `"`)+`)`)
+		if err != nil {
+			return nil, err
+		}
+
+		table := sqlTable{Schema: "main", Name: name}
+		pkOrder := make(map[int]string)
+		for info.Next() {
+			var cid, notNull, pk int
+			var colName, colType string
+			var defaultValue sql.NullString
+			if err := info.Scan(&cid, &colName, &colType, &notNull, &defaultValue, &pk); err != nil {
+				info.Close()
+				return nil, err
+			}
+			table.Columns = append(table.Columns, sqlColumn{Name: colName, DataType: colType})
+			if pk > 0 {
+				pkOrder[pk] = colName
+			}
+		}
+		info.Close()
+
+		for i := 1; i <= len(pkOrder); i++ {
+			table.PrimaryKey = append(table.PrimaryKey, pkOrder[i])
+		}
+		// Rowid tables without an explicit key still have a rowid
+		if len(table.PrimaryKey) == 0 {
+			table.PrimaryKey = []string{"rowid"}
+		}
+
+		tables = append(tables, table)
+	}
+
+	return tables, nil
+}
+
+func (sqliteDialect) selectQuery(table sqlTable, columns []string, limit int) string {
+	query := "SELECT " + quoteIdents(columns, `"`, `"`) + " FROM " + quoteIdent(table.Name, `"`, `"`)
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", limit)
+	}
+	return query
+}
+
+// SQLite columns are dynamically typed: a column declared INT can hold
+// text, and INTEGER is 64-bit, so every column can hold a PAN
+func (sqliteDialect) scannable(dataType string) bool {
+	return true
+}
+
+// informationSchemaDialect covers PostgreSQL, MySQL and SQL Server,
+// which all expose the ANSI information_schema views
+type informationSchemaDialect struct {
+	quote    string   // Opening identifier quote
+	quoteEnd string   // Closing identifier quote
+	top      bool     // Use SELECT TOP n instead of LIMIT n (SQL Server)
+	excluded []string // System schemas to skip
+}
+
+func (d informationSchemaDialect) listTables(ctx context.Context, db *sql.DB) ([]sqlTable, error) {
+	// Only base tables (not views) - views would report the same data twice
+	rows, err := db.QueryContext(ctx, `
+		SELECT c.table_schema, c.table_name, c.column_name, c.data_type
+		FROM information_schema.columns c
+		JOIN information_schema.tables t
+		  ON t.table_schema = c.table_schema AND t.table_name = c.table_name
+		WHERE t.table_type = 'BASE TABLE'
+		ORDER BY c.table_schema, c.table_name, c.ordinal_position`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	excluded := make(map[string]bool)
+	for _, schema := range d.excluded {
+		excluded[schema] = true
+	}
+
+	byName := make(map[string]*sqlTable)
+	var order []string
+
+	for rows.Next() {
+		var schema, tableName, colName, dataType string
+		if err := rows.Scan(&schema, &tableName, &colName, &dataType); err != nil {
+			return nil, err
+		}
+		if excluded[schema] || strings.HasPrefix(schema, "pg_") {
+			continue
+		}
+
+		key := schema + "." + tableName
+		table, ok := byName[key]
+		if !ok {
+			table = &sqlTable{Schema: schema, Name: tableName}
+			byName[key] = table
+			order = append(order, key)
+		}
+		table.Columns = append(table.Columns, sqlColumn{Name: colName, DataType: dataType})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// Primary keys (best effort - tables without one are still scanned)
+	pkRows, err := db.QueryContext(ctx, `
+		SELECT k.table_schema, k.table_name, k.column_name
+		FROM information_schema.table_constraints tc
+		JOIN information_schema.key_column_usage k
+		  ON k.constraint_name = tc.constraint_name
+		 AND k.table_schema = tc.table_schema
+		 AND k.table_name = tc.table_name
+		WHERE tc.constraint_type = 'PRIMARY KEY'
+		ORDER BY k.table_schema, k.table_name, k.ordinal_position`)
+	if err == nil {
+		defer pkRows.Close()
+		for pkRows.Next() {
+			var schema, tableName, colName string
+			if err := pkRows.Scan(&schema, &tableName, &colName); err != nil {
+				break
+			}
+			if table, ok := byName[schema+"."+tableName]; ok {
+				table.PrimaryKey = append(table.PrimaryKey, colName)
+			}
+		}
+	}
+
+	tables := make([]sqlTable, 0, len(order))
+	for _, key := range order {
+		tables = append(tables, *byName[key])
+	}
+	return tables, nil
+}
+
+func (d informationSchemaDialect) selectQuery(table sqlTable, columns []string, limit int) string {
+	from := quoteIdent(table.Schema, d.quote, d.quoteEnd) + "." + quoteIdent(table.Name, d.quote, d.quoteEnd)
+	cols := quoteIdents(columns, d.quote, d.quoteEnd)
+
+	if limit > 0 && d.top {
+		return fmt.Sprintf("SELECT TOP %d %s FROM %s", limit, cols, from)
+	}
+	query := "SELECT " + cols + " FROM " + from
+	if limit > 0 {
+		query += fmt.Sprintf(" LIMIT %d", limit)
+	}
+	return query
+}
+
+func (d informationSchemaDialect) scannable(dataType string) bool {
+	return isScannableColumnType(dataType)
+}
+
+// ============================================================
+// HELPER FUNCTIONS
+// ============================================================
+
+// isScannableColumnType reports whether a column type can hold a PAN
+//
+// Text-like types are always scanned. Numeric types are scanned only if
+// they may be wide enough for 13-19 digits (BIGINT, INTEGER, NUMERIC,
+// DECIMAL). An empty (unknown) type is scanned too. SQLite columns are
+// all scanned, whatever their declared type (see sqliteDialect).
+//
+// Example:
+//
+//	isScannableColumnType("character varying") // true
+//	isScannableColumnType("bigint")            // true
+//	isScannableColumnType("integer")           // true
+//	isScannableColumnType("boolean")           // false
+func isScannableColumnType(dataType string) bool {
+	t := strings.ToLower(strings.TrimSpace(dataType))
+	if t == "" {
+		return true
+	}
+
+	for _, keyword := range []string{
+		"char", "text", "clob", "string", "json", "xml", "enum", "set",
+		"bigint", "int8", "integer", "numeric", "decimal", "number",
+	} {
+		if strings.Contains(t, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatPrimaryKey renders primary key values as "col=value,col=value"
+// A table keyed by the card number would otherwise put the full PAN in
+// every report, so card numbers in key values are masked
+//
+// Example:
+//
+//	formatPrimaryKey([]string{"pan"}, values, issuers) // "pan=453201******0366"
+func formatPrimaryKey(columns []string, values []sql.NullString, issuers detector.IssuerResolver) string {
+	parts := make([]string, len(columns))
+	for i, col := range columns {
+		value := "NULL"
+		if values[i].Valid {
+			value = maskCards(values[i].String, issuers)
+		}
+		parts[i] = col + "=" + value
+	}
+	return strings.Join(parts, ",")
+}
+
+// maskCards replaces every card number detected in text with its
+// masked form ("4532-0151-1283-0366" -> "453201******0366")
+func maskCards(text string, issuers detector.IssuerResolver) string {
+	locations := detector.DetectCardsInFile(text, issuers)
+
+	// Last card first, so earlier indexes stay valid
+	for i := len(locations) - 1; i >= 0; i-- {
+		loc := locations[i]
+		if loc.StartIndex < 0 || loc.EndIndex > len(text) || loc.StartIndex >= loc.EndIndex {
+			continue
+		}
+		text = text[:loc.StartIndex] + detector.MaskCardNumber(loc.CardNumber) + text[loc.EndIndex:]
+	}
+	return text
+}
+
+// columnNames returns the names of a column list
+func columnNames(columns []sqlColumn) []string {
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = col.Name
+	}
+	return names
+}
+
+// quoteIdent quotes an identifier, doubling any embedded closing quote
+func quoteIdent(name, open, close string) string {
+	return open + strings.ReplaceAll(name, close, close+close) + close
+}
+
+// quoteIdents quotes and comma-joins a list of identifiers
+func quoteIdents(names []string, open, close string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = quoteIdent(name, open, close)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// dsnSecretParams are DSN keys and URL query parameters that carry
+// secrets (compared in lower case)
+var dsnSecretParams = map[string]bool{
+	"password":     true,
+	"pwd":          true,
+	"passwd":       true,
+	"pass":         true,
+	"sslpassword":  true,
+	"secret":       true,
+	"token":        true,
+	"access_token": true,
+	"auth_token":   true,
+	"api_key":      true,
+	"apikey":       true,
+}
+
+// RedactDSN hides the password in a DSN so it can be shown and reported
+//
+// The three DSN families are parsed, not pattern-matched, so quoted
+// passwords and values containing "@" or spaces are handled:
+//   - URLs: user info password and secret query parameters
+//   - MySQL: user:password@tcp(host)/db?params
+//   - Key/value (libpq, SQL Server): password = 'quoted value' ...
+//
+// Example:
+//
+//	RedactDSN("postgres://audit:secret@db/shop")                // "postgres://audit:xxxxx@db/shop"
+//	RedactDSN("postgres://db/shop?user=audit&password=secret")  // "postgres://db/shop?user=audit&password=xxxxx"
+//	RedactDSN("host=db user=audit password='my secret'")        // "host=db user=audit password=xxxxx"
+//	RedactDSN("Server=db;User Id=sa;Password=p@ss word;")       // "Server=db;User Id=sa;Password=xxxxx;"
+//	RedactDSN("audit:secret@tcp(db:3306)/shop?tls=true")        // "audit:xxxxx@tcp(db:3306)/shop?tls=true"
+func RedactDSN(dsn string) string {
+	if strings.Contains(dsn, "://") {
+		if u, err := url.Parse(dsn); err == nil {
+			if u.User != nil {
+				if _, hasPassword := u.User.Password(); hasPassword {
+					u.User = url.UserPassword(u.User.Username(), "xxxxx")
+				}
+			}
+			u.RawQuery = redactQuery(u.RawQuery)
+			return u.String()
+		}
+		// Not a valid URL (e.g. an unescaped character in the password):
+		// hide everything between "://" and the host
+		scheme := strings.Index(dsn, "://") + 3
+		if at := strings.LastIndex(dsn, "@"); at > scheme {
+			return dsn[:scheme] + "xxxxx" + dsn[at:]
+		}
+		return dsn
+	}
+
+	if isMySQLDSN(dsn) {
+		return redactMySQLDSN(dsn)
+	}
+	return redactKeyValueDSN(dsn)
+}
+
+// isMySQLDSN reports whether a DSN looks like user[:password]@proto(addr)/db
+// (an "@" before any "=" or whitespace, i.e. not inside a key/value pair)
+func isMySQLDSN(dsn string) bool {
+	at := strings.Index(dsn, "@")
+	if at < 0 {
+		return false
+	}
+	end := strings.IndexAny(dsn, "= \t\r\n")
+	return end < 0 || at < end
+}
+
+// redactMySQLDSN hides the password and secret parameters of a MySQL DSN
+// The password may contain "@": the last "@" before the database ends it
+func redactMySQLDSN(dsn string) string {
+	base, params, hasParams := strings.Cut(dsn, "?")
+
+	if at := strings.LastIndex(base, "@"); at > 0 {
+		if colon := strings.Index(base[:at], ":"); colon >= 0 {
+			base = base[:colon+1] + "xxxxx" + base[at:]
+		}
+	}
+
+	if hasParams {
+		return base + "?" + redactQuery(params)
+	}
+	return base
+}
+
+// redactKeyValueDSN hides secret values of a key/value DSN
+//
+// Two separators are understood:
+//   - libpq: "host=db password = 'a b\'c'" (whitespace separated,
+//     spaces around "=", single-quoted values with backslash escapes)
+//   - SQL Server / ODBC: "Server=db;Password=a b;" (";" separated,
+//     values may contain spaces, quoted values double their quote)
+//
+// Everything except the secret values is kept as written.
+func redactKeyValueDSN(dsn string) string {
+	semicolons := strings.Contains(dsn, ";")
+	isSpace := func(c byte) bool { return c == ' ' || c == '\t' || c == '\r' || c == '\n' }
+
+	var out strings.Builder
+	i := 0
+	for i < len(dsn) {
+		// Separators between pairs
+		if isSpace(dsn[i]) || dsn[i] == ';' {
+			out.WriteByte(dsn[i])
+			i++
+			continue
+		}
+
+		// Key: up to "=" (";"-style keys may contain spaces, e.g. "User Id")
+		keyStart := i
+		for i < len(dsn) && dsn[i] != '=' && dsn[i] != ';' && (semicolons || !isSpace(dsn[i])) {
+			i++
+		}
+		key := strings.TrimSpace(dsn[keyStart:i])
+		out.WriteString(dsn[keyStart:i])
+
+		// libpq allows whitespace before "="
+		for !semicolons && i < len(dsn) && isSpace(dsn[i]) {
+			out.WriteByte(dsn[i])
+			i++
+		}
+		if i >= len(dsn) || dsn[i] != '=' {
+			continue // A word without value: keep it as is
+		}
+		out.WriteByte('=')
+		i++
+
+		// Whitespace after "="
+		for i < len(dsn) && isSpace(dsn[i]) {
+			out.WriteByte(dsn[i])
+			i++
+		}
+
+		// Value: quoted, or up to the next separator
+		valueStart := i
+		if i < len(dsn) && (dsn[i] == '\'' || dsn[i] == '"') {
+			quote := dsn[i]
+			i++
+			for i < len(dsn) {
+				if dsn[i] == '\\' && !semicolons && i+1 < len(dsn) {
+					i += 2
+					continue
+				}
+				if dsn[i] == quote {
+					// A doubled quote is an escaped quote
+					if i+1 < len(dsn) && dsn[i+1] == quote {
+						i += 2
+						continue
+					}
+					i++
+					break
+				}
+				i++
+			}
+		} else {
+			for i < len(dsn) && dsn[i] != ';' && (semicolons || !isSpace(dsn[i])) {
+				i++
+			}
+		}
+
+		if dsnSecretParams[strings.ToLower(key)] {
+			out.WriteString("xxxxx")
+		} else {
+			out.WriteString(dsn[valueStart:i])
+		}
+	}
+
+	return out.String()
+}
+
+// redactQuery replaces the values of secret parameters in a raw URL
+// query, keeping the order and encoding of the other parameters
+//
+// Example:
+//
+//	redactQuery("sslmode=require&sslpassword=k3y") // "sslmode=require&sslpassword=xxxxx"
+func redactQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return rawQuery
+	}
+
+	params := strings.Split(rawQuery, "&")
+	for i, param := range params {
+		key, _, _ := strings.Cut(param, "=")
+		name, err := url.QueryUnescape(key)
+		if err != nil {
+			name = key
+		}
+		if dsnSecretParams[strings.ToLower(name)] {
+			params[i] = key + "=xxxxx"
+		}
+	}
+	return strings.Join(params, "&")
+}
@@ -0,0 +1,989 @@
+// Package scanner - SQLite Database Reader (Pure GO - Standard Library Only)
+// File: internal/scanner/sqlite_reader.go
+//
+// This file reads SQLite database files directly from the on-disk format
+// NO cgo, NO sqlite3 library, NO external tools - just the file format spec!
+//
+// WHY NOT SCAN THE RAW FILE?
+//
+//	❌ Integers are stored in binary (a PAN stored as INTEGER is invisible)
+//	❌ Long values are split across overflow pages
+//	❌ Line numbers mean nothing inside a binary database
+//
+// HOW SQLITE FILES WORK:
+//   - The file is a sequence of fixed-size pages (512-65536 bytes)
+//   - Page 1 starts with a 100-byte header (page size, text encoding, ...)
+//   - Every table is a B-tree; page 1 is the root of "sqlite_schema"
+//   - sqlite_schema lists every table with its root page and CREATE statement
+//   - Leaf pages contain cells: rowid + record (the row's column values)
+//   - Records that don't fit on the page continue on overflow pages
+//
+// WHAT WE EXTRACT:
+//
+//	✅ Every column value of every row in every table
+//	✅ TEXT values (UTF-8 and UTF-16 databases)
+//	✅ INTEGER and REAL values (converted to decimal text)
+//	✅ Values spanning overflow pages
+//	✅ WITHOUT ROWID tables (stored as index B-trees)
+//
+// WHAT WE DON'T EXTRACT:
+//
+//	❌ BLOB values (binary data, not text)
+//	❌ Deleted rows on freelist pages
+//	❌ Uncommitted data in -wal / -journal files
+//
+// Reference: https://www.sqlite.org/fileformat.html
+package scanner
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// ============================================================
+// CONSTANTS
+// ============================================================
+
+// sqliteMagic is the 16-byte header string every SQLite 3 database starts with
+const sqliteMagic = "SQLite format 3\x00"
+
+// B-tree page types (first byte of the page header)
+const (
+	sqlitePageInteriorIndex = 0x02 // Interior page of an index (or WITHOUT ROWID table)
+	sqlitePageInteriorTable = 0x05 // Interior page of a rowid table
+	sqlitePageLeafIndex     = 0x0A // Leaf page of an index (or WITHOUT ROWID table)
+	sqlitePageLeafTable     = 0x0D // Leaf page of a rowid table
+)
+
+// Text encodings (database header offset 56)
+const (
+	sqliteEncodingUTF8    = 1
+	sqliteEncodingUTF16LE = 2
+	sqliteEncodingUTF16BE = 3
+)
+
+// ============================================================
+// DATA STRUCTURES
+// ============================================================
+
+// SQLiteTable describes one table found in the sqlite_schema table
+type SQLiteTable struct {
+	Name         string   // Table name
+	RootPage     uint32   // Page number of the table's B-tree root
+	Columns      []string // Column names in declaration order
+	RowIDAlias   int      // Index of the INTEGER PRIMARY KEY column (-1 if none)
+	WithoutRowID bool     // true for WITHOUT ROWID tables (index B-tree layout)
+}
+
+// SQLiteCell is a single column value of a single row
+// This is what the scanner sees instead of a line of text
+type SQLiteCell struct {
+	Table  string // Table name
+	Column string // Column name
+	RowID  int64  // Row identifier (0 for WITHOUT ROWID tables)
+	Value  string // Column value converted to text
+}
+
+// SQLiteReader reads tables and rows from a SQLite database file
+type SQLiteReader struct {
+	ctx        context.Context // Checked for every page read (see file_limits.go)
+	file       *os.File        // Open database file
+	pageSize   int             // Size of each page in bytes
+	usableSize int             // Page size minus reserved bytes at the end of each page
+	pageCount  uint32          // Number of pages in the file
+	encoding   int             // Text encoding (UTF-8 / UTF-16LE / UTF-16BE)
+}
+
+// ============================================================
+// CONSTRUCTOR
+// ============================================================
+
+// NewSQLiteReader opens a SQLite database file and parses its header
+//
+// Parameters:
+//   - filePath: Path to the database file
+//
+// Returns:
+//   - *SQLiteReader: Reader ready to walk tables (call Close when done)
+//   - error: Error if the file can't be opened or isn't a SQLite 3 database
+//
+// Example:
+//
+//	reader, err := NewSQLiteReader("app.sqlite3")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer reader.Close()
+func NewSQLiteReader(filePath string) (*SQLiteReader, error) {
+	return NewSQLiteReaderContext(context.Background(), filePath)
+}
+
+// NewSQLiteReaderContext opens a SQLite database bound to a context
+//
+// Every page read checks ctx, so walking a large or cyclic B-tree stops
+// as soon as ctx is done (the walk returns ctx.Err()).
+//
+// Parameters:
+//   - ctx: Context that bounds the walk
+//   - filePath: Path to the database file
+//
+// Returns:
+//   - *SQLiteReader: Reader ready to walk tables (call Close when done)
+//   - error: Error if the file can't be opened or isn't a SQLite 3 database
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+//	defer cancel()
+//	reader, err := NewSQLiteReaderContext(ctx, "app.sqlite3")
+func NewSQLiteReaderContext(ctx context.Context, filePath string) (*SQLiteReader, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+
+	// Read the 100-byte database header
+	header := make([]byte, 100)
+	if _, err := file.ReadAt(header, 0); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to read database header: %w", err)
+	}
+
+	if string(header[:16]) != sqliteMagic {
+		file.Close()
+		return nil, errors.New("not a SQLite 3 database")
+	}
+
+	// Page size: 2 bytes big-endian at offset 16
+	// The value 1 means 65536 (doesn't fit in 2 bytes)
+	pageSize := int(binary.BigEndian.Uint16(header[16:18]))
+	if pageSize == 1 {
+		pageSize = 65536
+	}
+	if pageSize < 512 || pageSize&(pageSize-1) != 0 {
+		file.Close()
+		return nil, fmt.Errorf("invalid page size: %d", pageSize)
+	}
+
+	// Reserved space at the end of each page (used by extensions like encryption)
+	reserved := int(header[20])
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to stat database: %w", err)
+	}
+
+	reader := &SQLiteReader{
+		ctx:        ctx,
+		file:       file,
+		pageSize:   pageSize,
+		usableSize: pageSize - reserved,
+		pageCount:  uint32(info.Size() / int64(pageSize)),
+		encoding:   int(binary.BigEndian.Uint32(header[56:60])),
+	}
+
+	// Encoding 0 is written by very old versions - treat as UTF-8
+	if reader.encoding == 0 {
+		reader.encoding = sqliteEncodingUTF8
+	}
+
+	return reader, nil
+}
+
+// Close closes the underlying database file
+func (r *SQLiteReader) Close() error {
+	return r.file.Close()
+}
+
+// ============================================================
+// SCHEMA
+// ============================================================
+
+// Tables returns all tables listed in the sqlite_schema table
+//
+// Views, indexes and triggers are ignored - only tables hold data.
+// Internal tables (sqlite_sequence, sqlite_stat1, ...) are included
+// because they can hold copies of data too.
+//
+// Returns:
+//   - []SQLiteTable: Tables with their root pages and column names
+//   - error: Error if the schema B-tree can't be read
+func (r *SQLiteReader) Tables() ([]SQLiteTable, error) {
+	var tables []SQLiteTable
+
+	// sqlite_schema columns: type, name, tbl_name, rootpage, sql
+	// Its root page is always page 1
+	err := r.walkTableBTree(1, make(map[uint32]bool), func(rowID int64, values []sqliteValue) error {
+		if len(values) < 5 || values[0].text != "table" {
+			return nil
+		}
+
+		rootPage := values[3].integer
+		if rootPage <= 0 || rootPage > int64(r.pageCount) {
+			return nil // Virtual tables have rootpage 0
+		}
+
+		columns, aliasIndex, withoutRowID := parseCreateTable(values[4].text)
+		tables = append(tables, SQLiteTable{
+			Name:         values[1].text,
+			RootPage:     uint32(rootPage),
+			Columns:      columns,
+			RowIDAlias:   aliasIndex,
+			WithoutRowID: withoutRowID,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema: %w", err)
+	}
+
+	return tables, nil
+}
+
+// ============================================================
+// ROW WALKING
+// ============================================================
+
+// WalkCells calls fn for every non-empty text-like value in the database
+//
+// BLOBs and NULLs are skipped. INTEGER and REAL values are converted to
+// their decimal representation so a PAN stored as a number is still found.
+//
+// Parameters:
+//   - fn: Callback for each cell; returning an error stops the walk
+//
+// Returns:
+//   - error: Error from fn, or error if the database is corrupted
+//
+// Example:
+//
+//	err := reader.WalkCells(func(cell SQLiteCell) error {
+//	    fmt.Printf("%s.%s[%d] = %s\n", cell.Table, cell.Column, cell.RowID, cell.Value)
+//	    return nil
+//	})
+func (r *SQLiteReader) WalkCells(fn func(SQLiteCell) error) error {
+	tables, err := r.Tables()
+	if err != nil {
+		return err
+	}
+
+	for _, table := range tables {
+		// Each table gets its own visited set - pages are never shared
+		// between tables, so a repeat means the file is corrupted
+		visited := make(map[uint32]bool)
+
+		emit := func(rowID int64, values []sqliteValue) error {
+			for i, value := range values {
+				// INTEGER PRIMARY KEY columns are stored as NULL,
+				// the real value is the rowid
+				if i == table.RowIDAlias && value.kind == sqliteNull {
+					value = sqliteValue{kind: sqliteInteger, integer: rowID}
+				}
+
+				text, ok := value.String()
+				if !ok || text == "" {
+					continue
+				}
+
+				err := fn(SQLiteCell{
+					Table:  table.Name,
+					Column: columnName(table.Columns, i),
+					RowID:  rowID,
+					Value:  text,
+				})
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		if table.WithoutRowID {
+			err = r.walkIndexBTree(table.RootPage, visited, func(values []sqliteValue) error {
+				return emit(0, values)
+			})
+		} else {
+			err = r.walkTableBTree(table.RootPage, visited, emit)
+		}
+		if err != nil {
+			return fmt.Errorf("table %s: %w", table.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// walkTableBTree visits every row of a rowid table B-tree (depth-first)
+//
+// Parameters:
+//   - pageNum: Page number of the (sub)tree root
+//   - visited: Pages already visited (protects against cycles in corrupt files)
+//   - fn: Callback for each row
+func (r *SQLiteReader) walkTableBTree(pageNum uint32, visited map[uint32]bool, fn func(int64, []sqliteValue) error) error {
+	page, headerOffset, err := r.readBTreePage(pageNum, visited)
+	if err != nil {
+		return err
+	}
+
+	pageType := page[headerOffset]
+	cellCount := int(binary.BigEndian.Uint16(page[headerOffset+3:]))
+
+	switch pageType {
+	case sqlitePageInteriorTable:
+		// Interior page: each cell = 4-byte left child page + varint key
+		// Right-most child pointer is in the page header at offset 8
+		cellPointers := headerOffset + 12
+		for i := 0; i < cellCount; i++ {
+			cellOffset, err := cellPointer(page, cellPointers, i)
+			if err != nil || cellOffset+4 > len(page) {
+				return fmt.Errorf("page %d: bad cell pointer", pageNum)
+			}
+			child := binary.BigEndian.Uint32(page[cellOffset:])
+			if err := r.walkTableBTree(child, visited, fn); err != nil {
+				return err
+			}
+		}
+		rightMost := binary.BigEndian.Uint32(page[headerOffset+8:])
+		return r.walkTableBTree(rightMost, visited, fn)
+
+	case sqlitePageLeafTable:
+		// Leaf page: each cell = varint payload size + varint rowid + payload
+		cellPointers := headerOffset + 8
+		for i := 0; i < cellCount; i++ {
+			cellOffset, err := cellPointer(page, cellPointers, i)
+			if err != nil {
+				return fmt.Errorf("page %d: bad cell pointer", pageNum)
+			}
+
+			payloadSize, n := readVarint(page[cellOffset:])
+			cellOffset += n
+			rowID, n := readVarint(page[cellOffset:])
+			cellOffset += n
+
+			payload, err := r.readPayload(page, cellOffset, payloadSize, r.tableMaxLocal())
+			if err != nil {
+				return fmt.Errorf("page %d: %w", pageNum, err)
+			}
+
+			values, err := r.decodeRecord(payload)
+			if err != nil {
+				continue // Skip undecodable rows, keep going
+			}
+			if err := fn(int64(rowID), values); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("page %d: unexpected page type 0x%02x in table B-tree", pageNum, pageType)
+	}
+}
+
+// walkIndexBTree visits every record of an index B-tree (depth-first)
+// Used for WITHOUT ROWID tables, whose rows live in index-style pages
+func (r *SQLiteReader) walkIndexBTree(pageNum uint32, visited map[uint32]bool, fn func([]sqliteValue) error) error {
+	page, headerOffset, err := r.readBTreePage(pageNum, visited)
+	if err != nil {
+		return err
+	}
+
+	pageType := page[headerOffset]
+	cellCount := int(binary.BigEndian.Uint16(page[headerOffset+3:]))
+
+	interior := pageType == sqlitePageInteriorIndex
+	if !interior && pageType != sqlitePageLeafIndex {
+		return fmt.Errorf("page %d: unexpected page type 0x%02x in index B-tree", pageNum, pageType)
+	}
+
+	cellPointers := headerOffset + 8
+	if interior {
+		cellPointers = headerOffset + 12
+	}
+
+	for i := 0; i < cellCount; i++ {
+		cellOffset, err := cellPointer(page, cellPointers, i)
+		if err != nil {
+			return fmt.Errorf("page %d: bad cell pointer", pageNum)
+		}
+
+		// Interior index cells carry a record too, after the child pointer
+		if interior {
+			if cellOffset+4 > len(page) {
+				return fmt.Errorf("page %d: bad cell pointer", pageNum)
+			}
+			child := binary.BigEndian.Uint32(page[cellOffset:])
+			if err := r.walkIndexBTree(child, visited, fn); err != nil {
+				return err
+			}
+			cellOffset += 4
+		}
+
+		payloadSize, n := readVarint(page[cellOffset:])
+		cellOffset += n
+
+		payload, err := r.readPayload(page, cellOffset, payloadSize, r.indexMaxLocal())
+		if err != nil {
+			return fmt.Errorf("page %d: %w", pageNum, err)
+		}
+
+		values, err := r.decodeRecord(payload)
+		if err != nil {
+			continue
+		}
+		if err := fn(values); err != nil {
+			return err
+		}
+	}
+
+	if interior {
+		rightMost := binary.BigEndian.Uint32(page[headerOffset+8:])
+		return r.walkIndexBTree(rightMost, visited, fn)
+	}
+	return nil
+}
+
+// ============================================================
+// PAGE ACCESS
+// ============================================================
+
+// readPage reads a whole page from disk (pages are numbered from 1)
+// All B-tree and overflow reads go through here, so this is where the
+// walk notices a cancelled context
+func (r *SQLiteReader) readPage(pageNum uint32) ([]byte, error) {
+	if err := r.ctx.Err(); err != nil {
+		return nil, err
+	}
+	if pageNum == 0 || pageNum > r.pageCount {
+		return nil, fmt.Errorf("page %d out of range (1-%d)", pageNum, r.pageCount)
+	}
+
+	page := make([]byte, r.pageSize)
+	offset := int64(pageNum-1) * int64(r.pageSize)
+	if _, err := r.file.ReadAt(page, offset); err != nil {
+		return nil, fmt.Errorf("failed to read page %d: %w", pageNum, err)
+	}
+	return page, nil
+}
+
+// readBTreePage reads a B-tree page and returns the offset of its header
+// Page 1 has the 100-byte database header in front of the B-tree header
+func (r *SQLiteReader) readBTreePage(pageNum uint32, visited map[uint32]bool) ([]byte, int, error) {
+	if visited[pageNum] {
+		return nil, 0, fmt.Errorf("page %d visited twice (corrupted B-tree)", pageNum)
+	}
+	visited[pageNum] = true
+
+	page, err := r.readPage(pageNum)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	headerOffset := 0
+	if pageNum == 1 {
+		headerOffset = 100
+	}
+	if headerOffset+12 > len(page) {
+		return nil, 0, fmt.Errorf("page %d too small", pageNum)
+	}
+
+	return page, headerOffset, nil
+}
+
+// cellPointer returns the offset of the i-th cell on a page
+func cellPointer(page []byte, arrayOffset, i int) (int, error) {
+	pos := arrayOffset + i*2
+	if pos+2 > len(page) {
+		return 0, errors.New("cell pointer array out of bounds")
+	}
+	offset := int(binary.BigEndian.Uint16(page[pos:]))
+	if offset >= len(page) {
+		return 0, errors.New("cell offset out of bounds")
+	}
+	return offset, nil
+}
+
+// tableMaxLocal is the largest payload stored entirely on a table leaf page
+func (r *SQLiteReader) tableMaxLocal() int {
+	return r.usableSize - 35
+}
+
+// indexMaxLocal is the largest payload stored entirely on an index page
+func (r *SQLiteReader) indexMaxLocal() int {
+	return (r.usableSize-12)*64/255 - 23
+}
+
+// readPayload assembles a cell payload, following overflow pages if needed
+//
+// Overflow rules (from the file format spec):
+//   - P = payload size, X = max local size, U = usable page size
+//   - If P <= X, the whole payload is on the page
+//   - Otherwise M = ((U-12)*32/255)-23, K = M+((P-M)%(U-4))
+//     and K bytes (or M if K > X) stay local, the rest is on overflow pages
+//   - Each overflow page = 4-byte next page number + up to U-4 bytes of data
+func (r *SQLiteReader) readPayload(page []byte, offset int, payloadSize uint64, maxLocal int) ([]byte, error) {
+	// A single value can't be larger than the whole file
+	if payloadSize > uint64(r.pageCount)*uint64(r.pageSize) {
+		return nil, fmt.Errorf("payload size %d exceeds database size", payloadSize)
+	}
+	size := int(payloadSize)
+
+	localSize := size
+	if size > maxLocal {
+		minLocal := (r.usableSize-12)*32/255 - 23
+		localSize = minLocal + (size-minLocal)%(r.usableSize-4)
+		if localSize > maxLocal {
+			localSize = minLocal
+		}
+	}
+
+	if offset+localSize > len(page) {
+		return nil, errors.New("cell payload out of bounds")
+	}
+
+	payload := make([]byte, 0, size)
+	payload = append(payload, page[offset:offset+localSize]...)
+
+	if localSize == size {
+		return payload, nil
+	}
+
+	// Follow the overflow chain
+	if offset+localSize+4 > len(page) {
+		return nil, errors.New("overflow pointer out of bounds")
+	}
+	next := binary.BigEndian.Uint32(page[offset+localSize:])
+	visited := make(map[uint32]bool)
+
+	for next != 0 && len(payload) < size {
+		if visited[next] {
+			return nil, errors.New("overflow chain loops")
+		}
+		visited[next] = true
+
+		overflow, err := r.readPage(next)
+		if err != nil {
+			return nil, err
+		}
+
+		chunk := r.usableSize - 4
+		if remaining := size - len(payload); remaining < chunk {
+			chunk = remaining
+		}
+		payload = append(payload, overflow[4:4+chunk]...)
+		next = binary.BigEndian.Uint32(overflow[:4])
+	}
+
+	if len(payload) < size {
+		return nil, errors.New("overflow chain ended early")
+	}
+
+	return payload, nil
+}
+
+// ============================================================
+// RECORD DECODING
+// ============================================================
+
+// sqliteValueKind is the storage class of a decoded value
+type sqliteValueKind int
+
+const (
+	sqliteNull sqliteValueKind = iota
+	sqliteInteger
+	sqliteFloat
+	sqliteText
+	sqliteBlob
+)
+
+// sqliteValue is a single decoded column value
+type sqliteValue struct {
+	kind    sqliteValueKind
+	integer int64
+	float   float64
+	text    string
+}
+
+// String converts a value to text for scanning
+// Returns false for NULL and BLOB values (nothing to scan)
+func (v sqliteValue) String() (string, bool) {
+	switch v.kind {
+	case sqliteInteger:
+		return strconv.FormatInt(v.integer, 10), true
+	case sqliteFloat:
+		// 'f' with -1 precision prints integral floats without exponent
+		// Example: 4.532015112830366e15 → "4532015112830366"
+		return strconv.FormatFloat(v.float, 'f', -1, 64), true
+	case sqliteText:
+		return v.text, true
+	default:
+		return "", false
+	}
+}
+
+// decodeRecord decodes a record (row payload) into column values
+//
+// RECORD FORMAT:
+//   - Header: varint header size, then one varint "serial type" per column
+//   - Body: column values back-to-back, sized by their serial types
+//
+// Serial types:
+//
+//	0 = NULL, 1-6 = big-endian int (1,2,3,4,6,8 bytes), 7 = float64,
+//	8 = integer 0, 9 = integer 1, N>=12 even = BLOB of (N-12)/2 bytes,
+//	N>=13 odd = TEXT of (N-13)/2 bytes
+func (r *SQLiteReader) decodeRecord(payload []byte) ([]sqliteValue, error) {
+	headerSize, n := readVarint(payload)
+	if n == 0 || headerSize > uint64(len(payload)) {
+		return nil, errors.New("invalid record header")
+	}
+
+	var values []sqliteValue
+	headerPos := n
+	bodyPos := int(headerSize)
+
+	for headerPos < int(headerSize) {
+		serialType, n := readVarint(payload[headerPos:int(headerSize)])
+		if n == 0 {
+			return nil, errors.New("invalid serial type")
+		}
+		headerPos += n
+
+		// Corrupted headers can hold huge serial types whose size
+		// overflows int: check the size before adding it
+		size := serialTypeSize(serialType)
+		if size < 0 || size > len(payload)-bodyPos {
+			return nil, errors.New("record body out of bounds")
+		}
+		data := payload[bodyPos : bodyPos+size]
+		bodyPos += size
+
+		var value sqliteValue
+		switch {
+		case serialType == 0:
+			value.kind = sqliteNull
+		case serialType >= 1 && serialType <= 6:
+			value.kind = sqliteInteger
+			value.integer = decodeBigEndianInt(data)
+		case serialType == 7:
+			value.kind = sqliteFloat
+			value.float = math.Float64frombits(binary.BigEndian.Uint64(data))
+		case serialType == 8:
+			value.kind = sqliteInteger
+			value.integer = 0
+		case serialType == 9:
+			value.kind = sqliteInteger
+			value.integer = 1
+		case serialType >= 12 && serialType%2 == 0:
+			value.kind = sqliteBlob
+		case serialType >= 13:
+			value.kind = sqliteText
+			value.text = r.decodeText(data)
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// serialTypeSize returns the number of body bytes used by a serial type
+func serialTypeSize(serialType uint64) int {
+	switch serialType {
+	case 0, 8, 9, 10, 11:
+		return 0
+	case 1:
+		return 1
+	case 2:
+		return 2
+	case 3:
+		return 3
+	case 4:
+		return 4
+	case 5:
+		return 6
+	case 6, 7:
+		return 8
+	}
+	if serialType%2 == 0 {
+		return int((serialType - 12) / 2)
+	}
+	return int((serialType - 13) / 2)
+}
+
+// decodeBigEndianInt decodes a signed big-endian integer of 1-8 bytes
+func decodeBigEndianInt(data []byte) int64 {
+	var value int64
+	for _, b := range data {
+		value = value<<8 | int64(b)
+	}
+	// Sign-extend from the actual width
+	shift := uint(64 - 8*len(data))
+	return value << shift >> shift
+}
+
+// decodeText converts TEXT bytes to a Go string using the database encoding
+func (r *SQLiteReader) decodeText(data []byte) string {
+	if r.encoding == sqliteEncodingUTF8 || len(data) < 2 {
+		return string(data)
+	}
+
+	units := make([]uint16, len(data)/2)
+	for i := range units {
+		if r.encoding == sqliteEncodingUTF16LE {
+			units[i] = binary.LittleEndian.Uint16(data[i*2:])
+		} else {
+			units[i] = binary.BigEndian.Uint16(data[i*2:])
+		}
+	}
+	return string(utf16.Decode(units))
+}
+
+// readVarint decodes a SQLite varint (1-9 bytes, big-endian, 7 bits per byte)
+// The 9th byte, if present, contributes all 8 bits
+//
+// Returns the value and the number of bytes consumed (0 if data is too short)
+func readVarint(data []byte) (uint64, int) {
+	var value uint64
+	for i := 0; i < 9; i++ {
+		if i >= len(data) {
+			return 0, 0
+		}
+		b := data[i]
+		if i == 8 {
+			return value<<8 | uint64(b), 9
+		}
+		value = value<<7 | uint64(b&0x7f)
+		if b&0x80 == 0 {
+			return value, i + 1
+		}
+	}
+	return value, 9
+}
+
+// ============================================================
+// CREATE TABLE PARSING
+// ============================================================
+
+// parseCreateTable extracts column names from a CREATE TABLE statement
+//
+// This is NOT a full SQL parser - it splits the column list on top-level
+// commas and takes the first token of each column definition.
+//
+// Parameters:
+//   - sql: CREATE TABLE statement from sqlite_schema
+//
+// Returns:
+//   - []string: Column names (in storage order: for WITHOUT ROWID tables
+//     the PRIMARY KEY columns come first)
+//   - int: Index of the INTEGER PRIMARY KEY column (-1 if none)
+//   - bool: true if the table is WITHOUT ROWID
+//
+// Example:
+//
+//	parseCreateTable(`CREATE TABLE "users" (id INTEGER PRIMARY KEY, card TEXT)`)
+//	// Returns: ["id", "card"], 0, false
+func parseCreateTable(sql string) ([]string, int, bool) {
+	open := strings.Index(sql, "(")
+	closeIdx := strings.LastIndex(sql, ")")
+	if open < 0 || closeIdx <= open {
+		return nil, -1, false
+	}
+
+	withoutRowID := strings.Contains(
+		strings.ToUpper(strings.Join(strings.Fields(sql[closeIdx+1:]), " ")),
+		"WITHOUT ROWID")
+
+	var columns, primaryKey []string
+	aliasIndex := -1
+
+	for _, def := range splitTopLevel(sql[open+1 : closeIdx]) {
+		def = strings.TrimSpace(def)
+		if def == "" {
+			continue
+		}
+
+		// Table constraints are not columns
+		upper := strings.ToUpper(def)
+		if keyAt := strings.Index(upper, "PRIMARY KEY"); keyAt >= 0 &&
+			(strings.HasPrefix(upper, "CONSTRAINT") || keyAt == 0) {
+			primaryKey = parseKeyColumns(def[keyAt+len("PRIMARY KEY"):])
+			continue
+		}
+		if strings.HasPrefix(upper, "CONSTRAINT") ||
+			strings.HasPrefix(upper, "UNIQUE") || strings.HasPrefix(upper, "CHECK") ||
+			strings.HasPrefix(upper, "FOREIGN KEY") {
+			continue
+		}
+
+		name, rest := firstSQLToken(def)
+		columns = append(columns, name)
+		if hasKeywords(rest, "PRIMARY", "KEY") {
+			primaryKey = []string{name}
+		}
+
+		// "INTEGER PRIMARY KEY" (exactly INTEGER) makes the column an alias for rowid
+		fields := strings.Fields(strings.ToUpper(rest))
+		if len(fields) >= 3 && fields[0] == "INTEGER" && fields[1] == "PRIMARY" && fields[2] == "KEY" {
+			aliasIndex = len(columns) - 1
+		}
+	}
+
+	if withoutRowID {
+		// WITHOUT ROWID records store the PRIMARY KEY columns first
+		// (in key order), then the other columns in declaration order
+		aliasIndex = -1
+		columns = primaryKeyFirst(columns, primaryKey)
+	}
+
+	return columns, aliasIndex, withoutRowID
+}
+
+// parseKeyColumns extracts the column names of a table-level key
+// constraint: "(a, b DESC) ON CONFLICT ..." -> ["a", "b"]
+func parseKeyColumns(list string) []string {
+	open := strings.Index(list, "(")
+	closeIdx := strings.LastIndex(list, ")")
+	if open < 0 || closeIdx <= open {
+		return nil
+	}
+
+	var names []string
+	for _, part := range splitTopLevel(list[open+1 : closeIdx]) {
+		// Drop COLLATE / ASC / DESC after the name
+		if name, _ := firstSQLToken(strings.TrimSpace(part)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// hasKeywords reports whether a column definition contains the keywords
+// next to each other (case-insensitive), e.g. "PRIMARY", "KEY"
+func hasKeywords(def string, keywords ...string) bool {
+	fields := strings.Fields(strings.ToUpper(def))
+	for i := 0; i+len(keywords) <= len(fields); i++ {
+		match := true
+		for j, keyword := range keywords {
+			if fields[i+j] != keyword {
+				match = false
+				break
+			}
+		}
+		if match {
+			return true
+		}
+	}
+	return false
+}
+
+// primaryKeyFirst reorders column names into WITHOUT ROWID storage order
+//
+// Example:
+//
+//	primaryKeyFirst([]string{"note", "card", "id"}, []string{"id"})
+//	// Returns: ["id", "note", "card"]
+func primaryKeyFirst(columns, primaryKey []string) []string {
+	if len(primaryKey) == 0 {
+		return columns
+	}
+
+	ordered := make([]string, 0, len(columns))
+	inKey := make(map[string]bool)
+	for _, key := range primaryKey {
+		for _, column := range columns {
+			if strings.EqualFold(column, key) && !inKey[strings.ToLower(column)] {
+				ordered = append(ordered, column)
+				inKey[strings.ToLower(column)] = true
+			}
+		}
+	}
+	for _, column := range columns {
+		if !inKey[strings.ToLower(column)] {
+			ordered = append(ordered, column)
+		}
+	}
+	return ordered
+}
+
+// splitTopLevel splits a column list on commas that are not inside
+// parentheses or quotes (e.g. DECIMAL(10,2) or DEFAULT 'a,b')
+func splitTopLevel(s string) []string {
+	var parts []string
+	depth := 0
+	var quote byte
+	start := 0
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '[':
+			quote = ']'
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, s[start:])
+}
+
+// firstSQLToken returns the first identifier of a column definition
+// (unquoted) and the remainder of the definition
+func firstSQLToken(def string) (string, string) {
+	if def == "" {
+		return "", ""
+	}
+
+	var closing byte
+	switch def[0] {
+	case '"':
+		closing = '"'
+	case '`':
+		closing = '`'
+	case '[':
+		closing = ']'
+	case '\'':
+		closing = '\''
+	}
+
+	if closing != 0 {
+		end := strings.IndexByte(def[1:], closing)
+		if end >= 0 {
+			return def[1 : end+1], def[end+2:]
+		}
+	}
+
+	end := strings.IndexAny(def, " \t\r\n")
+	if end < 0 {
+		return def, ""
+	}
+	return def[:end], def[end:]
+}
+
+// columnName returns the i-th column name, or a positional name if the
+// CREATE TABLE statement couldn't be parsed (e.g. ALTER TABLE ADD COLUMN)
+func columnName(columns []string, i int) string {
+	if i < len(columns) && columns[i] != "" {
+		return columns[i]
+	}
+	return fmt.Sprintf("column%d", i+1)
+}
@@ -0,0 +1,174 @@
+// Package scanner - Directory walker with symlink and filesystem policy
+// File: internal/scanner/walk.go
+//
+// filepath.Walk never follows symlinks, happily descends into other
+// filesystems (NFS mounts, /proc) and hands FIFOs and devices to the
+// caller like regular files - reading a FIFO blocks forever. walkTree
+// replaces it with an explicit policy:
+//
+//	Symlinks        links to files are followed (as filepath.Walk callers
+//	                always did by reading the path); links to directories
+//	                are skipped and counted, or followed (Config.FollowSymlinks)
+//	                with loop detection: a directory is never walked twice
+//	Mount points    crossed, or skipped and counted (Config.OneFileSystem)
+//	Special files   passed to the callback, which skips and counts them
+//	                (FIFOs, sockets, devices have nothing to scan)
+//
+// The root is always resolved, even when it is a symlink itself.
+package scanner
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// treeWalker holds the state of one walkTree call
+type treeWalker struct {
+	config  *Config
+	result  *ScanResult     // Receives symlink and mount point counters
+	rootDev uint64          // Device of the root (one-filesystem mode)
+	hasDev  bool            // rootDev is known
+	visited map[string]bool // fileKey of everything already walked (follow mode)
+	fn      filepath.WalkFunc
+}
+
+// walkTree walks the tree below root like filepath.Walk, applying the
+// symlink and mount point policy of config
+//
+// Parameters:
+//   - root: Directory or file to walk
+//   - config: FollowSymlinks / OneFileSystem settings
+//   - result: Receives SkippedSymlinks and SkippedMounts (each skip is
+//     also reported to config.ProgressCallback)
+//   - fn: Called for every directory and file (with the target's info
+//     for followed symlinks); returning filepath.SkipDir
+//     for a directory skips it
+//
+// Returns:
+//   - error: Error returned by fn (e.g. for an inaccessible root)
+//
+// Example:
+//
+//	err := walkTree("/srv", config, result, func(path string, info os.FileInfo, err error) error {
+//	    ...
+//	})
+func walkTree(root string, config *Config, result *ScanResult, fn filepath.WalkFunc) error {
+	info, err := os.Stat(root)
+	if err != nil {
+		return fn(root, nil, err)
+	}
+
+	w := &treeWalker{
+		config:  config,
+		result:  result,
+		visited: make(map[string]bool),
+		fn:      fn,
+	}
+	w.rootDev, w.hasDev = fileDevice(info)
+	if config.FollowSymlinks {
+		w.visited[fileKey(root, info)] = true
+	}
+
+	err = w.walk(root, info)
+	if err == filepath.SkipDir {
+		return nil
+	}
+	return err
+}
+
+// walk visits path and, for directories, everything below it
+func (w *treeWalker) walk(path string, info os.FileInfo) error {
+	if !info.IsDir() {
+		return w.fn(path, info, nil)
+	}
+
+	if err := w.fn(path, info, nil); err != nil {
+		if err == filepath.SkipDir {
+			return nil
+		}
+		return err
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		if err := w.fn(path, info, err); err != nil && err != filepath.SkipDir {
+			return err
+		}
+		return nil
+	}
+
+	for _, entry := range entries {
+		child := filepath.Join(path, entry.Name())
+
+		childInfo, err := os.Lstat(child)
+		if err != nil {
+			if err := w.fn(child, nil, err); err != nil && err != filepath.SkipDir {
+				return err
+			}
+			continue
+		}
+
+		// Symlinks: continue with the target's info, unless the target
+		// is a directory and directory links aren't followed
+		isLink := childInfo.Mode()&os.ModeSymlink != 0
+		if isLink {
+			target, err := os.Stat(child)
+			if err != nil {
+				// Broken link or link loop the OS gave up on
+				skipFile(w.config, &w.result.SkippedSymlinks, child, SkipReasonSymlink)
+				continue
+			}
+			if target.IsDir() && !w.config.FollowSymlinks {
+				skipFile(w.config, &w.result.SkippedSymlinks, child, SkipReasonSymlink)
+				continue
+			}
+			childInfo = target
+		}
+
+		// With links followed, the same directory or file can be reached
+		// through several paths: walk it once (this also breaks loops)
+		if w.config.FollowSymlinks {
+			key := fileKey(child, childInfo)
+			if w.visited[key] {
+				if isLink && childInfo.IsDir() {
+					// Loop or second link to a directory
+					skipFile(w.config, &w.result.SkippedSymlinks, child, SkipReasonSymlink)
+				}
+				continue
+			}
+			w.visited[key] = true
+		}
+
+		// Mount points: stay on the root's filesystem if asked to
+		if childInfo.IsDir() && w.config.OneFileSystem && w.hasDev {
+			if dev, ok := fileDevice(childInfo); ok && dev != w.rootDev {
+				skipFile(w.config, &w.result.SkippedMounts, child, SkipReasonMount)
+				continue
+			}
+		}
+
+		if err := w.walk(child, childInfo); err != nil {
+			if err == filepath.SkipDir {
+				if childInfo.IsDir() {
+					continue
+				}
+				return nil // SkipDir from a file skips the rest of the directory
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// realPath resolves symlinks in path (the path itself if that fails)
+func realPath(path string) string {
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return path
+	}
+	if abs, err := filepath.Abs(resolved); err == nil {
+		return abs
+	}
+	return resolved
+}
@@ -0,0 +1,18 @@
+//go:build !unix
+
+// Package scanner - File identity without Unix stat data
+// File: internal/scanner/walk_other.go
+package scanner
+
+import "os"
+
+// fileDevice is not available here: one-filesystem mode can't detect
+// mount points (junctions are symlinks and are not followed by default)
+func fileDevice(info os.FileInfo) (uint64, bool) {
+	return 0, false
+}
+
+// fileKey identifies a file by its resolved path
+func fileKey(path string, info os.FileInfo) string {
+	return realPath(path)
+}
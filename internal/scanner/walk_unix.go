@@ -0,0 +1,30 @@
+//go:build unix
+
+// Package scanner - File identity from Unix stat data
+// File: internal/scanner/walk_unix.go
+package scanner
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// fileDevice returns the device (filesystem) a file lives on
+func fileDevice(info os.FileInfo) (uint64, bool) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, false
+	}
+	return uint64(stat.Dev), true
+}
+
+// fileKey identifies a file independently of the path used to reach it
+// (device and inode), for symlink loop and duplicate detection
+func fileKey(path string, info os.FileInfo) string {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return realPath(path)
+	}
+	return fmt.Sprintf("%d:%d", uint64(stat.Dev), uint64(stat.Ino))
+}
@@ -0,0 +1,179 @@
+// Package ui - Help screens
+// File: internal/ui/help.go
+//
+// Help is generated from the command definitions in cmd/scanner: each
+// command supplies its name, summary and the flag set it parses, so the
+// help can't drift from the options that actually exist.
+package ui
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// Command describes one command for the help screens
+type Command struct {
+	// Name is the command as typed: "scan", "report convert"
+	Name string
+
+	// Args is the usage after the name: "[options] <report.json>"
+	Args string
+
+	// Summary is the one-line description in the command list
+	Summary string
+
+	// Description is shown above the options (may span several lines)
+	Description string
+
+	// Groups are titled flag sections, in order
+	// Flags not listed in any group are shown under "Options"
+	Groups []FlagGroup
+
+	// Examples are shown at the end ("# ..." lines are comments)
+	Examples []string
+}
+
+// FlagGroup is a titled section of flags in a command's help
+type FlagGroup struct {
+	Title string
+	Flags []string // Flag names without the dash
+}
+
+// ShowHelp displays the command overview
+// This is shown when user runs with -help, "help" or no arguments
+//
+// Parameters:
+//   - version: Application version
+//   - commands: All commands, in display order
+func ShowHelp(version string, commands []Command) {
+	fmt.Printf("\nBasicPanScanner v%s - PCI Compliance Scanner\n\n", version)
+	fmt.Print("Usage: ./scanner <command> [options]\n\n")
+
+	fmt.Println("Commands:")
+	for _, cmd := range commands {
+		printEntry(cmd.Name, cmd.Summary)
+	}
+	printEntry("help <command>", "Show the options of a command")
+
+	fmt.Print(`
+A command line that starts with an option runs 'scan', so
+    ./scanner -path /var/log
+is the same as
+    ./scanner scan -path /var/log
+
+Configuration:
+    Edit config.json to set default mode and extension lists.
+    CLI flags always override config values.
+    './scanner config show-effective [scan options]' prints the result.
+
+Performance:
+    Default workers: CPU cores / 2 (safe for production)
+    More workers = faster scanning (2-4x speed improvement)
+
+Supported Card Types:
+    Visa, Mastercard, Amex, Discover, Diners Club, JCB,
+    UnionPay, Maestro, RuPay, Troy, Mir
+
+`)
+}
+
+// ShowCommandGroup lists the actions of a command group
+// Used for "./scanner bindb" without an action
+//
+// Parameters:
+//   - group: Group name ("bindb", "config", "report")
+//   - commands: Commands in the group
+func ShowCommandGroup(group string, commands []Command) {
+	fmt.Printf("\nUsage: ./scanner %s <action> [options]\n\n", group)
+	fmt.Println("Actions:")
+	for _, cmd := range commands {
+		printEntry(strings.TrimPrefix(cmd.Name, group+" "), cmd.Summary)
+	}
+	fmt.Printf("\nRun './scanner help %s <action>' for the options of an action.\n\n", group)
+}
+
+// ShowCommandHelp displays the usage and options of one command
+//
+// Parameters:
+//   - cmd: Command to describe
+//   - flags: The flag set the command parses
+//
+// Flag placeholders come from back-quoted words in the flag usage
+// (flag.UnquoteUsage): "Output `file`" is shown as "-output <file>".
+func ShowCommandHelp(cmd Command, flags *flag.FlagSet) {
+	fmt.Printf("\nUsage: ./scanner %s %s\n", cmd.Name, cmd.Args)
+	if cmd.Description != "" {
+		fmt.Printf("\n%s\n", strings.TrimRight(cmd.Description, "\n"))
+	}
+
+	// Grouped flags first, in the order given
+	listed := make(map[string]bool)
+	for _, group := range cmd.Groups {
+		fmt.Printf("\n%s:\n", group.Title)
+		for _, name := range group.Flags {
+			if f := flags.Lookup(name); f != nil {
+				printFlag(f)
+				listed[name] = true
+			}
+		}
+	}
+
+	// Everything else under "Options"
+	var rest []*flag.Flag
+	flags.VisitAll(func(f *flag.Flag) {
+		if !listed[f.Name] {
+			rest = append(rest, f)
+		}
+	})
+	if len(rest) > 0 {
+		fmt.Println("\nOptions:")
+		for _, f := range rest {
+			printFlag(f)
+		}
+	}
+
+	if len(cmd.Examples) > 0 {
+		fmt.Println("\nExamples:")
+		for _, line := range cmd.Examples {
+			if line == "" {
+				fmt.Println()
+				continue
+			}
+			fmt.Printf("    %s\n", line)
+		}
+	}
+	fmt.Println()
+}
+
+// printFlag prints one flag line: "    -output <file>        Save results ..."
+// The default value is added when the usage doesn't mention one
+func printFlag(f *flag.Flag) {
+	placeholder, usage := flag.UnquoteUsage(f)
+
+	name := "-" + f.Name
+	if placeholder != "" {
+		name += " <" + placeholder + ">"
+	}
+
+	switch f.DefValue {
+	case "", "0", "false", "[]":
+	default:
+		if !strings.Contains(usage, "default") {
+			usage += fmt.Sprintf(" (default: %s)", f.DefValue)
+		}
+	}
+
+	printEntry(name, usage)
+}
+
+// printEntry prints an aligned "name  description" line
+// Long names move the description to the next line
+func printEntry(name, description string) {
+	const width = 22
+	if len(name) > width {
+		fmt.Printf("    %s\n    %-*s %s\n", name, width, "", description)
+		return
+	}
+	fmt.Printf("    %-*s %s\n", width, name, description)
+}
@@ -112,6 +112,14 @@ func ShowScanInfo(directory, mode string, extensions, workers int, maxSize strin
 	fmt.Println(strings.Repeat("=", 60))
 }
 
+// SkipCount is an additional "skipped" line for the summary
+// (e.g., files skipped by path rules)
+type SkipCount struct {
+	Reason string // Shown as "Skipped (<reason>)"
+	Count  int    // Number of files (lines with 0 are not shown)
+	Failed bool   // Files that failed to scan: shown as "Unscanned (<reason>)"
+}
+
 // ShowSummary displays the final scan summary
 // This shows the complete results after scanning finishes
 //
@@ -123,11 +131,14 @@ func ShowScanInfo(directory, mode string, extensions, workers int, maxSize strin
 //   - skippedByExt: Files skipped by extension filter
 //   - cardsFound: Total cards found
 //   - scanRate: Files per second
+//   - otherSkips: Additional skip categories (optional)
 //
 // Example:
 //
-//	ui.ShowSummary(time.Minute, 1000, 800, 20, 180, 15, 13.3)
-func ShowSummary(duration time.Duration, totalFiles, scannedFiles, skippedBySize, skippedByExt, cardsFound int, scanRate float64) {
+//	ui.ShowSummary(time.Minute, 1000, 800, 20, 180, 15, 13.3,
+//	    ui.SkipCount{Reason: "path rules", Count: 42},
+//	    ui.SkipCount{Reason: "permission", Count: 3, Failed: true})
+func ShowSummary(duration time.Duration, totalFiles, scannedFiles, skippedBySize, skippedByExt, cardsFound int, scanRate float64, otherSkips ...SkipCount) {
 	fmt.Println("\n" + strings.Repeat("=", 60))
 	fmt.Printf("✓ Scan complete!\n")
 	fmt.Printf("  Time: %s\n", formatDuration(duration)) // Use formatted duration
@@ -142,6 +153,19 @@ func ShowSummary(duration time.Duration, totalFiles, scannedFiles, skippedBySize
 		fmt.Printf("  Skipped (extension): %d\n", skippedByExt)
 	}
 
+	for _, skip := range otherSkips {
+		if skip.Count > 0 && !skip.Failed {
+			fmt.Printf("  Skipped (%s): %d\n", skip.Reason, skip.Count)
+		}
+	}
+
+	// Failures last: files that were selected but NOT scanned
+	for _, skip := range otherSkips {
+		if skip.Count > 0 && skip.Failed {
+			fmt.Printf("  ⚠ Unscanned (%s): %d\n", skip.Reason, skip.Count)
+		}
+	}
+
 	fmt.Printf("  Cards found: %d\n", cardsFound)
 
 	if scanRate > 0 {
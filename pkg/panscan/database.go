@@ -0,0 +1,99 @@
+// Package panscan - Live database scanning
+// File: pkg/panscan/database.go
+//
+// Detector.ScanDatabase scans the tables of a database opened by the
+// caller. BasicPanScanner uses only the Go standard library, so no
+// database/sql driver is bundled: the embedding program imports the one
+// it needs (e.g. _ "github.com/lib/pq") and passes the *sql.DB.
+package panscan
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/keraattin/BasicPanScanner/internal/scanner"
+)
+
+// DatabaseOptions controls which tables ScanDatabase reads and how
+type DatabaseOptions struct {
+	// SQL dialect: "sqlite", "postgres", "mysql" or "sqlserver"
+	// (driver names such as "sqlite3", "pgx" and "mssql" are accepted)
+	Dialect string
+
+	// DSN labels the result (Result.Roots); the password is redacted
+	// It is not used to connect
+	DSN string
+
+	// Schemas and tables to scan (empty = all user schemas / all tables)
+	// Table names may be plain ("users") or qualified ("public.users")
+	Schemas []string
+	Tables  []string
+
+	// Rows read per table (0 = all rows, >0 = sample the first N)
+	RowLimit int
+
+	// Tables scanned concurrently (minimum 1)
+	Workers int
+
+	// Called after each table (optional, calls never overlap)
+	// Events carry the qualified table name as Path
+	OnProgress func(ProgressEvent)
+}
+
+// ScanDatabase scans the text-like columns of a live database
+//
+// A table plays the role of a file: findings are located by
+// schema.table.column and primary key, and tables that can't be read
+// are listed in Result.Errors. Cancelling ctx cancels the running
+// queries; the partial result is returned together with ctx.Err().
+//
+// Parameters:
+//   - ctx: Context controlling the whole scan
+//   - db: Open database (the caller registers the driver and closes it)
+//   - opts: Dialect and table selection
+//
+// Returns:
+//   - *Result: Findings, counters (RowsScanned) and per-table errors
+//   - error: Unsupported dialect, failed connection or catalog query,
+//     or ctx.Err()
+//
+// Example:
+//
+//	import _ "modernc.org/sqlite"
+//
+//	db, err := sql.Open("sqlite", "shop.db")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer db.Close()
+//
+//	result, err := d.ScanDatabase(ctx, db, panscan.DatabaseOptions{
+//	    Dialect:  "sqlite",
+//	    RowLimit: 1000,
+//	})
+//	for _, f := range result.Findings {
+//	    fmt.Printf("%s.%s [%s] %s\n", f.Table, f.Column, f.PrimaryKey, f.MaskedCard)
+//	}
+func (d *Detector) ScanDatabase(ctx context.Context, db *sql.DB, opts DatabaseOptions) (*Result, error) {
+	if db == nil {
+		return nil, fmt.Errorf("no database given")
+	}
+
+	source, err := scanner.NewSQLSource(&scanner.SQLSourceConfig{
+		Driver:           opts.Dialect,
+		DSN:              opts.DSN,
+		DB:               db,
+		Schemas:          opts.Schemas,
+		Tables:           opts.Tables,
+		RowLimit:         opts.RowLimit,
+		Workers:          opts.Workers,
+		Issuers:          d.issuers(),
+		ProgressCallback: progressCallback(opts.OnProgress),
+	})
+	if err != nil {
+		return nil, err
+	}
+	result, err := source.ScanContext(ctx)
+	return fromResult(result), err
+}
@@ -0,0 +1,287 @@
+// Package panscan is the public API of BasicPanScanner
+// File: pkg/panscan/panscan.go
+//
+// Everything under internal/ may change between releases. This package is
+// the stable surface for programs that embed the detector:
+//
+//	LoadBINDatabase        load a BIN database (no global state)
+//	ParseBINDatabase       build one from JSON in memory (tests, remote feeds)
+//	Detector               find cards in strings and readers, look up issuers
+//	Mask / ValidLuhn       PCI-style masking and checksum validation
+//	Detector.Scan          scan files and directories with Options
+//	Detector.ScanDatabase  scan the tables of a live database (any driver)
+//
+// Each Detector carries its own BIN database, so several detectors (with
+// different databases) can live in one process and nothing needs to be
+// initialised at startup.
+//
+// Example:
+//
+//	db, err := panscan.LoadBINDatabase("")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	d, err := panscan.NewDetector(db)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//
+//	for _, match := range d.Detect("order paid with 4532015112830366") {
+//	    fmt.Printf("%s %s (line %d)\n", match.CardType, match.MaskedCard, match.LineNumber)
+//	}
+package panscan
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/keraattin/BasicPanScanner/internal/detector"
+)
+
+// ============================================================
+// BIN DATABASE
+// ============================================================
+
+// BINDatabase is a loaded BIN database, safe for concurrent use
+type BINDatabase struct {
+	db *detector.BINDatabase
+}
+
+// Version returns the database version
+func (b *BINDatabase) Version() string {
+	return b.db.GetVersion()
+}
+
+// LastUpdated returns the date the database was last updated
+func (b *BINDatabase) LastUpdated() string {
+	return b.db.GetLastUpdated()
+}
+
+// Source returns where the database was loaded from
+// (a file path, or "embedded" for the bundled database)
+func (b *BINDatabase) Source() string {
+	return b.db.GetSource()
+}
+
+// Checksum returns the hex SHA-256 of the database JSON
+func (b *BINDatabase) Checksum() string {
+	return b.db.GetChecksum()
+}
+
+// Issuers returns the names of all active issuers, sorted by priority
+func (b *BINDatabase) Issuers() []string {
+	return b.db.GetAllIssuers()
+}
+
+// LoadBINDatabase loads a BIN database from a JSON file
+//
+// Parameters:
+//   - path: Path to a bin_ranges.json file ("" = the database bundled
+//     with the package, compiled into the binary)
+//
+// Returns:
+//   - *BINDatabase: Loaded database, safe for concurrent use
+//     (GetSource / GetChecksum identify the dataset)
+//   - error: Error if the file can't be read or is invalid
+//
+// Example:
+//
+//	db, err := panscan.LoadBINDatabase("/etc/panscan/bin_ranges.json")
+func LoadBINDatabase(path string) (*BINDatabase, error) {
+	if path == "" {
+		db, err := detector.LoadEmbeddedBINDatabase()
+		if err != nil {
+			return nil, err
+		}
+		return &BINDatabase{db: db}, nil
+	}
+
+	db, err := detector.NewBINDatabaseLoader().Load(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load BIN database: %w", err)
+	}
+	return &BINDatabase{db: db}, nil
+}
+
+// ParseBINDatabase builds a BIN database from bin_ranges.json content
+// Nothing is read from disk, so tests and services fetching their BIN
+// feed elsewhere can build a detector directly
+//
+// Parameters:
+//   - data: JSON in the bin_ranges.json format
+//
+// Returns:
+//   - *BINDatabase: Loaded database
+//   - error: Error if the JSON is invalid
+func ParseBINDatabase(data []byte) (*BINDatabase, error) {
+	db, err := detector.NewBINDatabaseLoader().Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse BIN database: %w", err)
+	}
+	return &BINDatabase{db: db}, nil
+}
+
+// ============================================================
+// DETECTOR
+// ============================================================
+
+// Match is a card found in a string or reader
+type Match struct {
+	CardNumber string // Normalized card number (digits only)
+	MaskedCard string // PCI-compliant masked version
+	CardType   string // Issuer name (e.g., "Visa", "MasterCard")
+	LineNumber int    // Line the card was found on (1-based)
+	StartIndex int    // Byte offset where the card starts
+	EndIndex   int    // Byte offset where the card ends
+}
+
+// Detector finds credit cards using its own BIN database
+// A Detector is safe for concurrent use
+type Detector struct {
+	db *BINDatabase
+}
+
+// NewDetector creates a detector using the given BIN database
+//
+// Parameters:
+//   - db: Database from LoadBINDatabase (nil = the database bundled
+//     with the package, like LoadBINDatabase(""))
+//
+// Returns:
+//   - *Detector: Detector ready to use
+//   - error: Error if db is nil and the bundled database can't be loaded
+//
+// Example:
+//
+//	d, err := panscan.NewDetector(nil) // bundled database
+func NewDetector(db *BINDatabase) (*Detector, error) {
+	if db == nil {
+		embedded, err := LoadBINDatabase("")
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the bundled BIN database: %w", err)
+		}
+		db = embedded
+	}
+	return &Detector{db: db}, nil
+}
+
+// issuers returns the detector's database as the scanner sees it
+func (d *Detector) issuers() *detector.BINDatabase {
+	return d.db.db
+}
+
+// Database returns the BIN database the detector uses
+func (d *Detector) Database() *BINDatabase {
+	return d.db
+}
+
+// Detect finds every card in text
+// A card that appears several times is reported once per occurrence
+//
+// Parameters:
+//   - text: Text to search
+//
+// Returns:
+//   - []Match: Cards found (issuer matched and Luhn valid), in order
+//
+// Example:
+//
+//	matches := d.Detect("card: 4532-0151-1283-0366")
+//	// matches[0].CardType = "Visa", matches[0].MaskedCard = "453201******0366"
+func (d *Detector) Detect(text string) []Match {
+	locations := detector.DetectCardsInFile(text, d.issuers())
+
+	matches := make([]Match, 0, len(locations))
+	for _, loc := range locations {
+		matches = append(matches, Match{
+			CardNumber: loc.CardNumber,
+			MaskedCard: detector.MaskCardNumber(loc.CardNumber),
+			CardType:   loc.CardType,
+			LineNumber: loc.LineNumber,
+			StartIndex: loc.StartIndex,
+			EndIndex:   loc.EndIndex,
+		})
+	}
+	return matches
+}
+
+// DetectReader reads r to the end and finds every card in it
+// The content is read as plain text (use Scan for PDFs, office files, ...)
+//
+// Parameters:
+//   - r: Reader to drain
+//
+// Returns:
+//   - []Match: Cards found
+//   - error: Read error
+func (d *Detector) DetectReader(r io.Reader) ([]Match, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input: %w", err)
+	}
+	return d.Detect(string(content)), nil
+}
+
+// Lookup returns the issuer of a card number
+// Separators (spaces, dashes) are ignored; the Luhn check is not applied
+//
+// Parameters:
+//   - cardNumber: Card number or its first digits padded to full length
+//
+// Returns:
+//   - string: Issuer name
+//   - bool: true if the BIN is in the database
+//
+// Example:
+//
+//	issuer, ok := d.Lookup("3782 822463 10005") // "Amex", true
+func (d *Detector) Lookup(cardNumber string) (string, bool) {
+	return d.issuers().MatchIssuer(normalize(cardNumber))
+}
+
+// Explain replays detection for one PAN, masked PAN or BIN: candidate
+// ranges with priorities and lengths, the winning issuer, and the
+// pipeline stage (format, issuer, luhn) that accepts or rejects it
+//
+// Example:
+//
+//	exp, err := d.Explain("6011000990139424")
+//	fmt.Println(exp.Issuer, exp.Accepted) // Discover true
+func (d *Detector) Explain(number string) (*Explanation, error) {
+	exp, err := d.issuers().Explain(number)
+	if err != nil {
+		return nil, err
+	}
+	return fromExplanation(exp), nil
+}
+
+// ============================================================
+// HELPERS
+// ============================================================
+
+// Mask returns the PCI-compliant masked form of a card number
+// (first 6 and last 4 digits kept, separators removed)
+//
+// Example:
+//
+//	panscan.Mask("4532 0151 1283 0366") // "453201******0366"
+func Mask(cardNumber string) string {
+	return detector.MaskCardNumber(normalize(cardNumber))
+}
+
+// ValidLuhn reports whether a card number passes the Luhn checksum
+// (separators removed)
+func ValidLuhn(cardNumber string) bool {
+	return detector.ValidateLuhn(normalize(cardNumber))
+}
+
+// normalize keeps only the digits of a card number
+func normalize(cardNumber string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, cardNumber)
+}
@@ -0,0 +1,117 @@
+// Package panscan - File and directory scanning
+// File: pkg/panscan/scan.go
+//
+// Detector.Scan runs the same scanner as the command line tool (content
+// sniffing, PDF/office/archive readers, per-file limits) with the
+// detector's BIN database and the given Options.
+package panscan
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/keraattin/BasicPanScanner/internal/filter"
+	"github.com/keraattin/BasicPanScanner/internal/scanner"
+)
+
+// Options controls which files Scan reads and how
+// The zero value scans every file, without size or time limits
+type Options struct {
+	// Extensions to scan (e.g. ".txt", ".log"); empty = all files
+	Extensions []string
+
+	// Extensions to skip; ignored when Extensions is set
+	ExcludeExtensions []string
+
+	// Directory names to skip (e.g. ".git", "node_modules")
+	ExcludeDirs []string
+
+	// Ordered glob/regex include/exclude rules ("+ **/*.log", "- re:^/proc/")
+	PathRules []string
+
+	// Select files by sniffed content: "extension" (default), "content" or "both"
+	TypeDetection string
+
+	// Walk into symlinked directories (symlinked files are always
+	// scanned) / stay on one filesystem
+	FollowSymlinks bool
+	OneFileSystem  bool
+
+	// Per-file limits (0 = no limit)
+	MaxFileSize   int64         // Larger files are skipped
+	FileTimeout   time.Duration // Slower files are recorded as timeout errors
+	MaxFileMemory int64         // Files needing more are recorded as too-large errors
+
+	// Callbacks (optional, calls never overlap)
+	OnProgress func(ProgressEvent)
+	OnFinding  func(Finding)
+}
+
+// Scan scans files and directories for credit cards
+//
+// Cancelling ctx stops the scan; the partial result is returned together
+// with ctx.Err().
+//
+// Parameters:
+//   - ctx: Context controlling the whole scan
+//   - paths: Directories and/or files to scan
+//   - opts: Scan options
+//
+// Returns:
+//   - *Result: Findings, counters and per-file errors
+//   - error: Invalid options, unreadable root or ctx.Err()
+//
+// Example:
+//
+//	result, err := d.Scan(ctx, []string{"/var/log"}, panscan.Options{
+//	    Extensions:  []string{".log", ".txt"},
+//	    FileTimeout: 30 * time.Second,
+//	})
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	fmt.Printf("%d cards in %d files\n", result.CardsFound, result.ScannedFiles)
+func (d *Detector) Scan(ctx context.Context, paths []string, opts Options) (*Result, error) {
+	config, err := d.scannerConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	result, err := scanner.NewScanner(config).ScanPathsContext(ctx, paths)
+	return fromResult(result), err
+}
+
+// scannerConfig translates Options into a scanner configuration
+func (d *Detector) scannerConfig(opts Options) (*scanner.Config, error) {
+	extFilter := filter.NewExtensionFilter("blacklist", nil, opts.ExcludeExtensions)
+	if len(opts.Extensions) > 0 {
+		extFilter = filter.NewExtensionFilter("whitelist", opts.Extensions, nil)
+	}
+
+	pathFilter, err := filter.NewPathFilter(opts.PathRules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid path rules: %w", err)
+	}
+
+	switch opts.TypeDetection {
+	case "", "extension", "content", "both":
+	default:
+		return nil, fmt.Errorf("invalid type detection %q (use extension, content or both)", opts.TypeDetection)
+	}
+
+	return &scanner.Config{
+		ExtFilter:        extFilter,
+		DirFilter:        filter.NewDirectoryFilter(opts.ExcludeDirs),
+		PathFilter:       pathFilter,
+		FollowSymlinks:   opts.FollowSymlinks,
+		OneFileSystem:    opts.OneFileSystem,
+		MaxFileSize:      opts.MaxFileSize,
+		FileTimeout:      opts.FileTimeout,
+		MaxFileMemory:    opts.MaxFileMemory,
+		Workers:          1,
+		TypeDetection:    opts.TypeDetection,
+		ProgressCallback: progressCallback(opts.OnProgress),
+		FindingCallback:  findingCallback(opts.OnFinding),
+		Issuers:          d.issuers(),
+	}, nil
+}
@@ -0,0 +1,334 @@
+// Package panscan - Public result types
+// File: pkg/panscan/types.go
+//
+// The scanner and detector packages are internal and change between
+// releases, so nothing of theirs is exposed here: every type a caller
+// can see is defined in this package and filled in at the boundary
+// (the from* functions below). Adding a field to an internal type never
+// changes this API; removing one is caught here at compile time.
+package panscan
+
+import (
+	"time"
+
+	"github.com/keraattin/BasicPanScanner/internal/detector"
+	"github.com/keraattin/BasicPanScanner/internal/scanner"
+)
+
+// ============================================================
+// FINDINGS
+// ============================================================
+
+// Finding is a card found by Scan or ScanDatabase
+type Finding struct {
+	FilePath   string    // File, s3:// URL or schema.table the card was found in
+	LineNumber int       // Line number (text content; 0 for databases)
+	CardType   string    // Issuer name (e.g., "Visa", "MasterCard")
+	CardNumber string    // Full card number (digits only)
+	MaskedCard string    // PCI-compliant masked version
+	Timestamp  time.Time // When the finding was made
+
+	// Database location (SQLite files and ScanDatabase)
+	Schema     string // Schema name (ScanDatabase only)
+	Table      string // Table name
+	Column     string // Column name
+	RowID      int64  // SQLite rowid (0 for WITHOUT ROWID tables or a rowid that is a card number)
+	PrimaryKey string // Primary key values, card numbers masked, e.g. "id=42" (ScanDatabase)
+}
+
+// Location returns a human-readable location of the finding
+//
+// Returns:
+//   - string: "Line 12" for text files,
+//     "users.card_number (rowid 5)" for SQLite files,
+//     "public.users.card_number [id=42]" for ScanDatabase
+func (f Finding) Location() string {
+	return scanner.Finding{
+		LineNumber: f.LineNumber,
+		Schema:     f.Schema,
+		Table:      f.Table,
+		Column:     f.Column,
+		RowID:      f.RowID,
+		PrimaryKey: f.PrimaryKey,
+	}.Location()
+}
+
+// fromFinding copies an internal finding into the public type
+func fromFinding(f scanner.Finding) Finding {
+	return Finding{
+		FilePath:   f.FilePath,
+		LineNumber: f.LineNumber,
+		CardType:   f.CardType,
+		CardNumber: f.CardNumber,
+		MaskedCard: f.MaskedCard,
+		Timestamp:  f.Timestamp,
+		Schema:     f.Schema,
+		Table:      f.Table,
+		Column:     f.Column,
+		RowID:      f.RowID,
+		PrimaryKey: f.PrimaryKey,
+	}
+}
+
+// fromFindings copies a slice of internal findings (nil stays nil)
+func fromFindings(findings []scanner.Finding) []Finding {
+	if findings == nil {
+		return nil
+	}
+	converted := make([]Finding, len(findings))
+	for i, f := range findings {
+		converted[i] = fromFinding(f)
+	}
+	return converted
+}
+
+// ============================================================
+// ERRORS
+// ============================================================
+
+// Scan stages a file error can happen in
+const (
+	StageWalk    = scanner.StageWalk    // Listing directories / stat of the file
+	StageRead    = scanner.StageRead    // Opening or reading the file (or table)
+	StageExtract = scanner.StageExtract // Extracting text (PDF, office, gzip, SQLite, ...)
+)
+
+// ErrorClass groups file errors by cause
+type ErrorClass string
+
+const (
+	ErrorPermission = ErrorClass(scanner.ErrorPermission) // Access denied
+	ErrorParse      = ErrorClass(scanner.ErrorParse)      // Corrupted, encrypted or unsupported content
+	ErrorTimeout    = ErrorClass(scanner.ErrorTimeout)    // Reading/parsing took too long
+	ErrorTooLarge   = ErrorClass(scanner.ErrorTooLarge)   // Content exceeds the size limit or memory budget
+	ErrorIO         = ErrorClass(scanner.ErrorIO)         // Other I/O errors (missing file, disk errors, ...)
+)
+
+// FileError describes a file (or table) that could not be (fully) scanned
+type FileError struct {
+	Path  string     // File or table that failed
+	Stage string     // StageWalk, StageRead or StageExtract
+	Class ErrorClass // Cause category
+	Err   error      // Underlying error
+}
+
+// Error implements the error interface
+func (e *FileError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the underlying error (for errors.Is / errors.As)
+func (e *FileError) Unwrap() error {
+	return e.Err
+}
+
+// fromFileError copies an internal file error into the public type
+func fromFileError(e scanner.FileError) FileError {
+	return FileError{
+		Path:  e.Path,
+		Stage: e.Stage,
+		Class: ErrorClass(e.Class),
+		Err:   e.Err,
+	}
+}
+
+// ============================================================
+// RESULTS
+// ============================================================
+
+// Result holds the outcome of Scan or ScanDatabase
+// (for ScanDatabase, tables play the role of files)
+type Result struct {
+	Roots            []string             // Paths (or redacted DSN) that were scanned
+	TotalFiles       int                  // Files found
+	ScannedFiles     int                  // Files actually scanned
+	SkippedBySize    int                  // Files skipped due to size
+	SkippedByExt     int                  // Files skipped by extension filter
+	SkippedByPath    int                  // Files skipped by path include/exclude rules
+	SkippedByContent int                  // Files skipped as binary/unsupported by content sniffing
+	SkippedSymlinks  int                  // Directory symlinks not followed (or broken / looping links)
+	SkippedSpecial   int                  // FIFOs, sockets and devices
+	SkippedMounts    int                  // Mount points not crossed (OneFileSystem)
+	CardsFound       int                  // Total credit cards found
+	RowsScanned      int64                // Database rows read (ScanDatabase only)
+	Findings         []Finding            // All findings
+	Errors           []FileError          // Files that could not be (fully) scanned
+	GroupedByFile    map[string][]Finding // Findings grouped by file
+	Duration         time.Duration        // How long the scan took
+	ScanRate         float64              // Files per second
+}
+
+// ErrorCounts returns the number of file errors per class
+//
+// Example:
+//
+//	counts := result.ErrorCounts()
+//	fmt.Println(counts[panscan.ErrorPermission]) // 3
+func (r *Result) ErrorCounts() map[ErrorClass]int {
+	counts := make(map[ErrorClass]int)
+	for _, fileErr := range r.Errors {
+		counts[fileErr.Class]++
+	}
+	return counts
+}
+
+// fromResult copies an internal scan result into the public type
+// (nil stays nil, so a failed scan still returns a nil *Result)
+func fromResult(r *scanner.ScanResult) *Result {
+	if r == nil {
+		return nil
+	}
+
+	result := &Result{
+		Roots:            r.Roots,
+		TotalFiles:       r.TotalFiles,
+		ScannedFiles:     r.ScannedFiles,
+		SkippedBySize:    r.SkippedBySize,
+		SkippedByExt:     r.SkippedByExt,
+		SkippedByPath:    r.SkippedByPath,
+		SkippedByContent: r.SkippedByContent,
+		SkippedSymlinks:  r.SkippedSymlinks,
+		SkippedSpecial:   r.SkippedSpecial,
+		SkippedMounts:    r.SkippedMounts,
+		CardsFound:       r.CardsFound,
+		RowsScanned:      r.RowsScanned,
+		Findings:         fromFindings(r.Findings),
+		GroupedByFile:    make(map[string][]Finding, len(r.GroupedByFile)),
+		Duration:         r.Duration,
+		ScanRate:         r.ScanRate,
+	}
+	for _, fileErr := range r.Errors {
+		result.Errors = append(result.Errors, fromFileError(fileErr))
+	}
+	for path, findings := range r.GroupedByFile {
+		result.GroupedByFile[path] = fromFindings(findings)
+	}
+	return result
+}
+
+// ============================================================
+// PROGRESS
+// ============================================================
+
+// EventType tells what happened to a file
+type EventType string
+
+const (
+	FileStarted  = EventType(scanner.FileStarted)  // Scan of the file begins
+	FileFinished = EventType(scanner.FileFinished) // File scanned
+	FileSkipped  = EventType(scanner.FileSkipped)  // File not selected by the filters
+	FileErrored  = EventType(scanner.FileErrored)  // File could not be (fully) scanned
+)
+
+// ProgressEvent describes one step of scanning a file (or table)
+type ProgressEvent struct {
+	Type     EventType
+	Path     string     // File or table the event is about
+	Reason   string     // FileSkipped: the skip counter's label ("size", "extension", ...)
+	Findings int        // FileFinished / FileErrored: cards found in this file
+	Err      *FileError // FileErrored: what went wrong
+
+	// Running totals (always set for started, finished and errored events)
+	Processed  int // Files finished or errored so far
+	Total      int // Files selected for scanning
+	CardsFound int // Cards found so far
+}
+
+// progressCallback adapts a public progress callback to the scanner's
+// (nil stays nil, so no event is converted for nobody)
+func progressCallback(onProgress func(ProgressEvent)) func(scanner.ProgressEvent) {
+	if onProgress == nil {
+		return nil
+	}
+	return func(e scanner.ProgressEvent) {
+		event := ProgressEvent{
+			Type:       EventType(e.Type),
+			Path:       e.Path,
+			Reason:     e.Reason,
+			Findings:   e.Findings,
+			Processed:  e.Processed,
+			Total:      e.Total,
+			CardsFound: e.CardsFound,
+		}
+		if e.Err != nil {
+			fileErr := fromFileError(*e.Err)
+			event.Err = &fileErr
+		}
+		onProgress(event)
+	}
+}
+
+// findingCallback adapts a public finding callback to the scanner's
+func findingCallback(onFinding func(Finding)) func(scanner.Finding) {
+	if onFinding == nil {
+		return nil
+	}
+	return func(f scanner.Finding) {
+		onFinding(fromFinding(f))
+	}
+}
+
+// ============================================================
+// EXPLANATIONS
+// ============================================================
+
+// Explanation is the result of Detector.Explain
+type Explanation struct {
+	Input      string      // The value as given
+	Digits     string      // The input without separators ("" for masked input)
+	BIN        string      // The first 6 digits
+	Length     int         // Card length (0 = BIN only, length unknown)
+	Masked     bool        // Some digits were hidden (*, x or #)
+	Issuer     string      // What Lookup returns for BIN and Length ("" = no match)
+	Candidates []Candidate // Every range containing the BIN, in lookup order
+	Stages     []Stage     // The detection pipeline, in order
+	Accepted   bool        // The pipeline would report this number
+}
+
+// Candidate is one BIN range containing the looked-up BIN
+type Candidate struct {
+	Issuer     string
+	Priority   int
+	RangeStart string // First BIN of the range (inclusive)
+	RangeEnd   string // Last BIN of the range (inclusive)
+	Lengths    []int
+	Active     bool
+	LengthOK   bool // The issuer supports the card length (always true when unknown)
+	Winner     bool // The range Lookup returns
+}
+
+// Stage is the outcome of one detection pipeline stage
+type Stage struct {
+	Name   string // "format", "issuer" or "luhn"
+	Status string // "pass", "fail" or "skip"
+	Detail string
+}
+
+// fromExplanation copies an internal explanation into the public type
+func fromExplanation(e *detector.Explanation) *Explanation {
+	exp := &Explanation{
+		Input:    e.Input,
+		Digits:   e.Digits,
+		BIN:      e.BIN,
+		Length:   e.Length,
+		Masked:   e.Masked,
+		Issuer:   e.Issuer,
+		Accepted: e.Accepted,
+	}
+	for _, c := range e.Candidates {
+		exp.Candidates = append(exp.Candidates, Candidate{
+			Issuer:     c.Issuer,
+			Priority:   c.Priority,
+			RangeStart: c.Range.Start,
+			RangeEnd:   c.Range.End,
+			Lengths:    c.Lengths,
+			Active:     c.Active,
+			LengthOK:   c.LengthOK,
+			Winner:     c.Winner,
+		})
+	}
+	for _, s := range e.Stages {
+		exp.Stages = append(exp.Stages, Stage{Name: s.Name, Status: s.Status, Detail: s.Detail})
+	}
+	return exp
+}